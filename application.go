@@ -6,3 +6,40 @@ type Application interface {
 	Update(deltaTime float32)
 	Render()
 }
+
+//FixedUpdater is an optional interface for Applications that need to update at a fixed rate, such as for deterministic physics.
+// FixedUpdate is called zero or more times each frame, before Update, once a rate has been set with SetFixedTimestep.
+type FixedUpdater interface {
+	FixedUpdate(deltaTime float32)
+}
+
+//Resizer is an optional interface for Applications that need to know when the canvas changes size. The size is in device pixels.
+type Resizer interface {
+	Resized(width, height int)
+}
+
+//ContextRestorer is an optional interface for Applications that create their own GL objects. ContextLost is called once the
+// context has been lost and the loop paused, while ContextRestored is called after every tracked resource has been restored.
+type ContextRestorer interface {
+	ContextLost()
+	ContextRestored()
+}
+
+//Stopper is an optional interface for Applications that need to clean up when they exit. Stop is called by Exit,
+// before the GL resources are released.
+type Stopper interface {
+	Stop()
+}
+
+//Pauser is an optional interface for Applications that need to know when they are paused, such as when the page is hidden.
+// No frames run between Pause and Resume.
+type Pauser interface {
+	Pause()
+	Resume()
+}
+
+//Focuser is an optional interface for Applications that need to know when the canvas gains or loses focus
+type Focuser interface {
+	Focus()
+	Blur()
+}
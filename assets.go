@@ -0,0 +1,364 @@
+package noodle
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+)
+
+//AssetKind is the type of value an asset loads into
+type AssetKind int
+
+const (
+	AssetTexture AssetKind = iota //AssetTexture is a *Texture
+	AssetShader                   //AssetShader is a *Shader
+	AssetFont                     //AssetFont is a *Font
+	AssetAudio                    //AssetAudio is the encoded bytes of a sound, ready to be decoded by the browser
+	AssetData                     //AssetData is the bytes of a file
+)
+
+//AssetState is how far an asset has loaded
+type AssetState int
+
+const (
+	AssetLoading AssetState = iota //AssetLoading is downloading or waiting for Assets.Update to finish it
+	AssetLoaded                    //AssetLoaded is ready to use
+	AssetFailed                    //AssetFailed could not be loaded, see Err
+)
+
+//Asset is a texture, shader, font or file that the Assets manager has loaded, or is loading
+type Asset struct {
+	Key  string
+	Kind AssetKind
+
+	state     AssetState
+	value     interface{}
+	err       error
+	refs      int
+	callbacks []func(*Asset)
+
+	load   func() (interface{}, error)            //load downloads and decodes the asset on its own goroutine
+	finish func(interface{}) (interface{}, error) //finish creates the GL objects from what was loaded, during Update
+	loaded interface{}                            //loaded is what load returned
+}
+
+//State gets how far the asset has loaded
+func (asset *Asset) State() AssetState { return asset.state }
+
+//Err gets why the asset failed to load
+func (asset *Asset) Err() error { return asset.err }
+
+//Value gets what the asset loaded into, or nil if it has not loaded
+func (asset *Asset) Value() interface{} { return asset.value }
+
+//Texture gets the loaded texture, or nil if the asset is not a loaded texture
+func (asset *Asset) Texture() *Texture {
+	texture, _ := asset.value.(*Texture)
+	return texture
+}
+
+//Shader gets the loaded shader, or nil if the asset is not a loaded shader
+func (asset *Asset) Shader() *Shader {
+	shader, _ := asset.value.(*Shader)
+	return shader
+}
+
+//Font gets the loaded font, or nil if the asset is not a loaded font
+func (asset *Asset) Font() *Font {
+	font, _ := asset.value.(*Font)
+	return font
+}
+
+//Data gets the loaded bytes of data and audio assets, or nil if the asset is not loaded bytes
+func (asset *Asset) Data() []byte {
+	data, _ := asset.value.([]byte)
+	return data
+}
+
+//OnLoad calls the callback once the asset has loaded or failed, from Assets.Update. If it already has, it is called straight away.
+func (asset *Asset) OnLoad(callback func(*Asset)) {
+	if asset.state != AssetLoading {
+		callback(asset)
+		return
+	}
+	asset.callbacks = append(asset.callbacks, callback)
+}
+
+//Assets loads textures, shaders, fonts, audio and data from Files by key, which is their name. Each is read on its own goroutine,
+// then finished by Update, as the GL objects have to be created from the frame loop. Loading a key that is already loaded
+// or loading as the same kind gives the same Asset, and counts another reference to it. Different kinds of asset are
+// kept apart, so a texture and a bitmap font can load the same image. The GL objects are released once every
+// reference has been released.
+//
+//Call Update every frame, such as from Application.Update, and use Progress to draw a loading screen.
+type Assets struct {
+	mu       sync.Mutex
+	assets   map[assetID]*Asset
+	finished []*Asset //finished have been downloaded and are waiting for Update
+	failed   []*Asset //failed are the assets of the current batch that failed, which are no longer cached
+
+	total  int //total is how many assets have been asked for since the last time every asset was done
+	loaded int //loaded is how many of those are done, including the ones that failed
+}
+
+//assetID identifies an asset by its kind and key
+type assetID struct {
+	kind AssetKind
+	key  string
+}
+
+//NewAssets creates an empty asset manager
+func NewAssets() *Assets {
+	return &Assets{assets: make(map[assetID]*Asset)}
+}
+
+//start gets the asset of the kind with the key, or starts loading a new one
+func (a *Assets) start(key string, kind AssetKind, load func() (interface{}, error), finish func(interface{}) (interface{}, error)) *Asset {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	id := assetID{kind, key}
+	if asset, ok := a.assets[id]; ok {
+		asset.refs++
+		return asset
+	}
+
+	//A new batch begins once everything before it is done, so the progress starts again from 0
+	if a.loaded == a.total {
+		a.total, a.loaded = 0, 0
+		a.failed = nil
+	}
+
+	asset := &Asset{Key: key, Kind: kind, refs: 1, load: load, finish: finish}
+	a.assets[id] = asset
+	a.total++
+
+	go func() {
+		loaded, err := asset.load()
+		a.mu.Lock()
+		asset.loaded, asset.err = loaded, err
+		a.finished = append(a.finished, asset)
+		a.mu.Unlock()
+	}()
+	return asset
+}
+
+//LoadTexture loads an image into a texture
+func (a *Assets) LoadTexture(url string) *Asset {
+	return a.start(url, AssetTexture, func() (interface{}, error) {
+		return LoadImage(url)
+	}, func(loaded interface{}) (interface{}, error) {
+		return NewTexture(loaded.(*Image)), nil
+	})
+}
+
+//LoadShader loads and preprocesses a vertex and fragment shader, which can #include files relative to themselves. Each
+// define is a name, such as "VERTEX_COLOR", or a name and value, such as "MAX_LIGHTS=4". The key is made from the URLs
+// and defines, like the variants of a ShaderLibrary, so use the Key of the asset to Get or Release it.
+func (a *Assets) LoadShader(vertURL, fragURL string, defines ...string) *Asset {
+	values := parseDefines(nil, defines)
+	return a.start(variantKey(vertURL, fragURL, values), AssetShader, func() (interface{}, error) {
+		vert, err := PreprocessShader(ReadString, vertURL, values)
+		if err != nil {
+			return nil, err
+		}
+		frag, err := PreprocessShader(ReadString, fragURL, values)
+		if err != nil {
+			return nil, err
+		}
+		return [2]*ShaderSource{vert, frag}, nil
+	}, func(loaded interface{}) (interface{}, error) {
+		sources := loaded.([2]*ShaderSource)
+		return LoadShaderSource(sources[0], sources[1])
+	})
+}
+
+//LoadFont loads a TrueType or OpenType font and draws the characters of the charset into an atlas at the size in pixels.
+// The key is made from the URL, size and charset, so use the Key of the asset to Get or Release it.
+func (a *Assets) LoadFont(url string, size float64, charset string) *Asset {
+	key := url + "\x00" + strconv.FormatFloat(size, 'g', -1, 64) + "\x00" + charset
+	return a.start(key, AssetFont, func() (interface{}, error) {
+		data, err := ReadFile(url)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := opentype.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	}, func(loaded interface{}) (interface{}, error) {
+		return LoadFont(loaded.(font.Face), charset), nil
+	})
+}
+
+//LoadFontBitmap loads an image of characters as a font, like the LoadFontBitmap function. The key is made from the URL,
+// charset and grid, so use the Key of the asset to Get or Release it.
+func (a *Assets) LoadFontBitmap(url, charset string, charPerLines, noLines int) *Asset {
+	key := url + "\x00" + charset + "\x00" + strconv.Itoa(charPerLines) + "x" + strconv.Itoa(noLines)
+	return a.start(key, AssetFont, func() (interface{}, error) {
+		return LoadImage(url)
+	}, func(loaded interface{}) (interface{}, error) {
+		return LoadFontBitmap(loaded.(*Image), charset, charPerLines, noLines), nil
+	})
+}
+
+//LoadAudio reads a sound. It is kept encoded, as the browser decodes audio itself.
+func (a *Assets) LoadAudio(url string) *Asset {
+	return a.start(url, AssetAudio, func() (interface{}, error) {
+		return ReadFile(url)
+	}, nil)
+}
+
+//LoadData reads a file
+func (a *Assets) LoadData(url string) *Asset {
+	return a.start(url, AssetData, func() (interface{}, error) {
+		return ReadFile(url)
+	}, nil)
+}
+
+//Get gets the asset of the kind with the key, or nil if it has not been loaded
+func (a *Assets) Get(kind AssetKind, key string) *Asset {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.assets[assetID{kind, key}]
+}
+
+//Update finishes the assets that have downloaded by creating their GL objects, then calls their OnLoad callbacks
+func (a *Assets) Update() {
+	a.mu.Lock()
+	finished := a.finished
+	a.finished = nil
+	a.mu.Unlock()
+
+	for _, asset := range finished {
+		switch {
+		case asset.err != nil:
+			asset.state = AssetFailed
+		case asset.finish != nil:
+			asset.value, asset.err = asset.finish(asset.loaded)
+			asset.state = AssetLoaded
+			if asset.err != nil {
+				asset.value, asset.state = nil, AssetFailed
+			}
+		default:
+			asset.value, asset.state = asset.loaded, AssetLoaded
+		}
+		asset.loaded = nil
+
+		a.mu.Lock()
+		a.loaded++
+		released := asset.refs <= 0
+
+		//Failed assets are forgotten, so loading them again tries again
+		if asset.state == AssetFailed && !released {
+			delete(a.assets, assetID{asset.Kind, asset.Key})
+			a.failed = append(a.failed, asset)
+		}
+		a.mu.Unlock()
+
+		//Every reference was released while it was loading
+		if released {
+			releaseAsset(asset)
+			continue
+		}
+
+		callbacks := asset.callbacks
+		asset.callbacks = nil
+		for _, callback := range callbacks {
+			callback(asset)
+		}
+	}
+}
+
+//Progress gets how much of the current batch of assets is done, from 0 to 1. A batch is every asset asked for since the
+// last time every asset was done, so it only goes back to 0 once the previous batch has finished.
+func (a *Assets) Progress() float32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.total == 0 {
+		return 1
+	}
+	return float32(a.loaded) / float32(a.total)
+}
+
+//Done checks if every asset has loaded or failed
+func (a *Assets) Done() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.loaded == a.total
+}
+
+//Failed gets the assets of the current batch that failed to load, sorted by key and then kind. Failed assets are not
+// cached, so loading one again tries again, and they do not need to be released.
+func (a *Assets) Failed() []*Asset {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	failed := append([]*Asset(nil), a.failed...)
+	sort.Slice(failed, func(i, j int) bool {
+		if failed[i].Key == failed[j].Key {
+			return failed[i].Kind < failed[j].Kind
+		}
+		return failed[i].Key < failed[j].Key
+	})
+	return failed
+}
+
+//Release releases a reference to the asset of the kind with the key. Once every reference is released the asset is
+// forgotten and its GL objects are released. Assets that are still loading are released once they finish.
+func (a *Assets) Release(kind AssetKind, key string) {
+	id := assetID{kind, key}
+	a.mu.Lock()
+	asset, ok := a.assets[id]
+	if !ok {
+		a.mu.Unlock()
+		return
+	}
+
+	asset.refs--
+	if asset.refs > 0 {
+		a.mu.Unlock()
+		return
+	}
+	delete(a.assets, id)
+	a.mu.Unlock()
+
+	if asset.state != AssetLoading {
+		releaseAsset(asset)
+	}
+}
+
+//ReleaseAll releases every asset, no matter how many references it has
+func (a *Assets) ReleaseAll() {
+	a.mu.Lock()
+	assets := a.assets
+	a.assets = make(map[assetID]*Asset)
+	a.failed = nil
+	for _, asset := range assets {
+		asset.refs = 0
+	}
+	a.mu.Unlock()
+
+	for _, asset := range assets {
+		if asset.state != AssetLoading {
+			releaseAsset(asset)
+		}
+	}
+}
+
+//releaseAsset releases the GL objects of an asset
+func releaseAsset(asset *Asset) {
+	switch value := asset.value.(type) {
+	case *Texture:
+		value.Release()
+	case *Shader:
+		value.Release()
+	case *Font:
+		value.Release()
+	}
+	asset.value = nil
+}
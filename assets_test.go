@@ -0,0 +1,307 @@
+package noodle
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+//useFiles sets Files for the rest of the test
+func useFiles(t *testing.T, files fs.FS) {
+	previous := Files
+	Files = files
+	t.Cleanup(func() { Files = previous })
+}
+
+//waitForAssets updates the assets until they are all done
+func waitForAssets(t *testing.T, assets *Assets) {
+	t.Helper()
+	updateUntil(t, assets, assets.Done)
+}
+
+//updateUntil updates the assets until the condition is true
+func updateUntil(t *testing.T, assets *Assets, condition func() bool) {
+	t.Helper()
+	for start := time.Now(); !condition(); assets.Update() {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("the assets did not finish loading")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+//gatedFS blocks opening the files that have a gate until the gate is closed
+type gatedFS struct {
+	fs.FS
+	gates map[string]chan struct{}
+}
+
+func (f gatedFS) Open(name string) (fs.File, error) {
+	if gate, ok := f.gates[name]; ok {
+		<-gate
+	}
+	return f.FS.Open(name)
+}
+
+//encodePNG encodes a blank image of the size
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return encoded.Bytes()
+}
+
+func TestAssetsKeepKindsApart(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	useFiles(t, fstest.MapFS{
+		"tiles.png":  {Data: encodePNG(t, 4, 2)},
+		"jump.sound": {Data: []byte{1, 2, 3}},
+	})
+
+	assets := NewAssets()
+	texture := assets.LoadTexture("tiles.png")
+	font := assets.LoadFontBitmap("tiles.png", "ab", 2, 1)
+	audio := assets.LoadAudio("jump.sound")
+	data := assets.LoadData("jump.sound")
+	if texture == font || audio == data {
+		t.Fatal("assets of different kinds with the same URL were shared")
+	}
+	waitForAssets(t, assets)
+
+	if texture.Texture() == nil || font.Font() == nil || audio.Data() == nil || data.Data() == nil {
+		t.Fatalf("expected every asset to load, but %v", assets.Failed())
+	}
+	if assets.Get(AssetTexture, "tiles.png") != texture || assets.Get(AssetFont, font.Key) != font {
+		t.Error("Get did not find the asset of each kind")
+	}
+
+	//Releasing the texture leaves the font's texture alone
+	platform.GL().ResetCalls()
+	assets.Release(AssetTexture, "tiles.png")
+	if len(callsNamed(platform.GL(), "deleteTexture")) != 1 || assets.Get(AssetFont, font.Key) != font {
+		t.Error("releasing the texture did not release only the texture")
+	}
+}
+
+func TestAssetsShaderKeys(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	useFiles(t, fstest.MapFS{
+		"a.vert": {Data: []byte(unlitVertCode)},
+		"a.frag": {Data: []byte(unlitFragCode)},
+	})
+
+	assets := NewAssets()
+	shader := assets.LoadShader("a.vert", "a.frag", "B=2", "A")
+	if assets.LoadShader("a.vert", "a.frag", "A", "B=2") != shader {
+		t.Error("the order of the defines changed the key")
+	}
+	if assets.LoadShader("a.vert", "a.frag", "A", "B=3") == shader {
+		t.Error("a different define value gave the same shader")
+	}
+
+	//The URLs can contain the characters that could separate them
+	if assets.LoadShader("x+y", "z") == assets.LoadShader("x", "y+z") {
+		t.Error("different files gave the same shader")
+	}
+	if assets.LoadShader("x", "y", "A#B") == assets.LoadShader("x", "y", "A", "B") {
+		t.Error("different defines gave the same shader")
+	}
+	waitForAssets(t, assets)
+
+	if shader.Shader() == nil {
+		t.Fatal(shader.Err())
+	}
+	if assets.Get(AssetShader, shader.Key) != shader {
+		t.Error("Get did not find the shader by its key")
+	}
+}
+
+func TestAssetsFontKeys(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	useFiles(t, fstest.MapFS{
+		"tiles.png":   {Data: encodePNG(t, 4, 2)},
+		"regular.ttf": {Data: goregular.TTF},
+	})
+
+	assets := NewAssets()
+	bitmap := assets.LoadFontBitmap("tiles.png", "ab", 2, 1)
+	if assets.LoadFontBitmap("tiles.png", "ab", 2, 1) != bitmap {
+		t.Error("the same bitmap font was loaded twice")
+	}
+	if assets.LoadFontBitmap("tiles.png", "cd", 2, 1) == bitmap || assets.LoadFontBitmap("tiles.png", "ab", 1, 2) == bitmap {
+		t.Error("a different charset or grid gave the same bitmap font")
+	}
+
+	face := assets.LoadFont("regular.ttf", 16, CharacterSetASCII)
+	if assets.LoadFont("regular.ttf", 16, CharacterSetASCII) != face {
+		t.Error("the same font was loaded twice")
+	}
+	if assets.LoadFont("regular.ttf", 16, CharacterSetASCII[1:]) == face || assets.LoadFont("regular.ttf", 24, CharacterSetASCII) == face {
+		t.Error("a different charset or size gave the same font")
+	}
+	waitForAssets(t, assets)
+
+	if face.Font() == nil || bitmap.Font() == nil {
+		t.Fatalf("expected the fonts to load, but %v", assets.Failed())
+	}
+	if assets.Get(AssetFont, face.Key) != face || assets.Get(AssetFont, bitmap.Key) != bitmap {
+		t.Error("Get did not find the fonts by their keys")
+	}
+}
+
+func TestAssetsCountReferences(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	useFiles(t, fstest.MapFS{"tiles.png": {Data: encodePNG(t, 4, 2)}})
+
+	assets := NewAssets()
+	texture := assets.LoadTexture("tiles.png")
+	waitForAssets(t, assets)
+	if assets.LoadTexture("tiles.png") != texture {
+		t.Fatal("loading a loaded texture did not give the same asset")
+	}
+
+	platform.GL().ResetCalls()
+	assets.Release(AssetTexture, "tiles.png")
+	if len(callsNamed(platform.GL(), "deleteTexture")) != 0 || assets.Get(AssetTexture, "tiles.png") != texture {
+		t.Fatal("the texture was released while it still had a reference")
+	}
+	assets.Release(AssetTexture, "tiles.png")
+	if len(callsNamed(platform.GL(), "deleteTexture")) != 1 || assets.Get(AssetTexture, "tiles.png") != nil || texture.Texture() != nil {
+		t.Fatal("the texture was not released with its last reference")
+	}
+
+	//Releasing again does nothing, and loading again loads a new asset
+	assets.Release(AssetTexture, "tiles.png")
+	if assets.LoadTexture("tiles.png") == texture {
+		t.Error("a released texture was loaded from the cache")
+	}
+	waitForAssets(t, assets)
+}
+
+func TestAssetsReleaseWhileLoading(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	gate := make(chan struct{})
+	useFiles(t, gatedFS{fstest.MapFS{"tiles.png": {Data: encodePNG(t, 4, 2)}}, map[string]chan struct{}{"tiles.png": gate}})
+
+	assets := NewAssets()
+	texture := assets.LoadTexture("tiles.png")
+	called := false
+	texture.OnLoad(func(*Asset) { called = true })
+	assets.Release(AssetTexture, "tiles.png")
+	if assets.Get(AssetTexture, "tiles.png") != nil {
+		t.Fatal("the texture was not forgotten when it was released")
+	}
+
+	//The texture is created once it has loaded, then released straight away
+	close(gate)
+	waitForAssets(t, assets)
+	if called || texture.Texture() != nil {
+		t.Error("the released texture was kept after it loaded")
+	}
+	if len(callsNamed(platform.GL(), "createTexture")) != len(callsNamed(platform.GL(), "deleteTexture")) {
+		t.Error("the released texture was not deleted")
+	}
+}
+
+func TestAssetsProgressAcrossBatches(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	gate := make(chan struct{})
+	useFiles(t, gatedFS{fstest.MapFS{
+		"a.bin": {Data: []byte{1}},
+		"b.bin": {Data: []byte{2}},
+		"c.bin": {Data: []byte{3}},
+		"d.bin": {Data: []byte{4}},
+	}, map[string]chan struct{}{"b.bin": gate}})
+
+	assets := NewAssets()
+	if assets.Progress() != 1 || !assets.Done() {
+		t.Fatal("expected nothing to load to be done")
+	}
+
+	assets.LoadData("a.bin")
+	assets.LoadData("b.bin")
+	updateUntil(t, assets, func() bool { return assets.Progress() == 0.5 })
+
+	//Assets asked for before the batch is done join it
+	assets.LoadData("c.bin")
+	updateUntil(t, assets, func() bool { return assets.Progress() == float32(2)/3 })
+	if assets.Done() {
+		t.Fatal("expected b to still be loading")
+	}
+	close(gate)
+	waitForAssets(t, assets)
+
+	//The next asset starts a new batch
+	assets.LoadData("d.bin")
+	if progress := assets.Progress(); progress != 0 {
+		t.Errorf("expected a new batch to start from 0, got %v", progress)
+	}
+	waitForAssets(t, assets)
+	if assets.Progress() != 1 {
+		t.Errorf("expected the batch to finish, got %v", assets.Progress())
+	}
+}
+
+func TestAssetsOnLoad(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	useFiles(t, fstest.MapFS{"a.bin": {Data: []byte{1}}})
+
+	assets := NewAssets()
+	var loaded []*Asset
+	data := assets.LoadData("a.bin")
+	missing := assets.LoadData("missing.bin")
+	data.OnLoad(func(asset *Asset) { loaded = append(loaded, asset) })
+	missing.OnLoad(func(asset *Asset) { loaded = append(loaded, asset) })
+	waitForAssets(t, assets)
+
+	if len(loaded) != 2 {
+		t.Fatalf("expected both callbacks to be called once, got %d calls", len(loaded))
+	}
+	if missing.State() != AssetFailed || missing.Err() == nil || data.State() != AssetLoaded {
+		t.Errorf("unexpected states %v and %v", data.State(), missing.State())
+	}
+
+	//Callbacks on an asset that is done are called straight away
+	data.OnLoad(func(asset *Asset) { loaded = append(loaded, asset) })
+	if len(loaded) != 3 || loaded[2] != data {
+		t.Error("the callback was not called straight away")
+	}
+}
+
+func TestAssetsRetryFailures(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	files := fstest.MapFS{"broken.png": {Data: []byte("not a png")}}
+	useFiles(t, files)
+
+	assets := NewAssets()
+	missing := assets.LoadData("missing.bin")
+	broken := assets.LoadTexture("broken.png")
+	waitForAssets(t, assets)
+
+	failed := assets.Failed()
+	if len(failed) != 2 || failed[0] != broken || failed[1] != missing {
+		t.Fatalf("expected both assets to fail, sorted by key, got %v", failed)
+	}
+	if broken.Err() == nil || assets.Get(AssetData, "missing.bin") != nil {
+		t.Error("expected the failed assets to be reported and not cached")
+	}
+
+	//Loading a failed asset again tries again
+	files["missing.bin"] = &fstest.MapFile{Data: []byte{1}}
+	retried := assets.LoadData("missing.bin")
+	if retried == missing {
+		t.Fatal("the failed asset was loaded from the cache")
+	}
+	waitForAssets(t, assets)
+	if retried.State() != AssetLoaded || len(assets.Failed()) != 0 {
+		t.Errorf("expected the retry to load, got %v and %v", retried.Err(), assets.Failed())
+	}
+}
@@ -0,0 +1,181 @@
+package noodle
+
+//Camera creates the matrices used to draw a 3D scene. Cameras look down their negative Z axis, with Y up.
+type Camera interface {
+	//ViewMatrix gets the matrix that transforms from world space into the space of the camera
+	ViewMatrix() Matrix
+	//ProjectionMatrix gets the matrix that transforms from the space of the camera into clip space
+	ProjectionMatrix() Matrix
+	//ScreenPointToRay gets the ray that goes from the camera through the point on the screen, in pixels
+	ScreenPointToRay(point Vector2) Ray
+	//Frustum gets the planes of the volume the camera can see
+	Frustum() Frustum
+}
+
+//CameraTransform is the position and rotation shared by the 3D cameras
+type CameraTransform struct {
+	Position Vector3    //Position is the position of the camera in world space
+	Rotation Quaternion //Rotation is the rotation of the camera in world space. With no rotation the camera looks down the negative Z axis.
+}
+
+//Forward gets the direction the camera is looking
+func (c *CameraTransform) Forward() Vector3 { return c.Rotation.Rotate(Vector3{0, 0, -1}) }
+
+//Up gets the direction that is up for the camera
+func (c *CameraTransform) Up() Vector3 { return c.Rotation.Rotate(Vector3{0, 1, 0}) }
+
+//Right gets the direction that is right of the camera
+func (c *CameraTransform) Right() Vector3 { return c.Rotation.Rotate(Vector3{1, 0, 0}) }
+
+//LookAt rotates the camera so it faces the target. If up is parallel to the direction of the target, another axis is
+// used as up. If the target is at the camera's position, the rotation is left as it is.
+func (c *CameraTransform) LookAt(target, up Vector3) {
+	f := target.Subtract(c.Position)
+	if f.Length() == 0 {
+		return
+	}
+	f = f.Normalize()
+
+	//The cross product of parallel vectors is zero, so pick the world axis furthest from the direction instead
+	s := f.CrossProduct(up)
+	if s.Length() < 1e-6 {
+		up = Vector3{0, 1, 0}
+		if Abs32(f.Y) > 0.9 {
+			up = Vector3{0, 0, -1}
+		}
+		s = f.CrossProduct(up)
+	}
+	s = s.Normalize()
+	u := s.CrossProduct(f)
+	c.Rotation = NewQuaternionMatrix(Matrix{
+		M0: s.X, M1: s.Y, M2: s.Z,
+		M4: u.X, M5: u.Y, M6: u.Z,
+		M8: -f.X, M9: -f.Y, M10: -f.Z,
+		M15: 1,
+	})
+}
+
+//ViewMatrix gets the matrix that transforms from world space into the space of the camera
+func (c *CameraTransform) ViewMatrix() Matrix {
+	return c.Rotation.ToMatrix().Transpose().Multiply(NewMatrixTranslate(c.Position.Negate()))
+}
+
+//PerspectiveCamera is a camera where things further away appear smaller
+type PerspectiveCamera struct {
+	CameraTransform
+	FieldOfView float32 //FieldOfView is the vertical field of view in degrees
+	Aspect      float32 //Aspect is the width divided by the height. If 0, the aspect of the screen is used so the camera keeps up with resizes.
+	Near        float32 //Near is the closest distance the camera can see
+	Far         float32 //Far is the furthest distance the camera can see
+}
+
+//NewPerspectiveCamera creates a new perspective camera at the origin. The field of view is in degrees.
+func NewPerspectiveCamera(fieldOfView, near, far float32) *PerspectiveCamera {
+	return &PerspectiveCamera{
+		CameraTransform: CameraTransform{Rotation: NewQuaternionIdentity()},
+		FieldOfView:     fieldOfView,
+		Near:            near,
+		Far:             far,
+	}
+}
+
+//ProjectionMatrix gets the perspective projection of the camera
+func (c *PerspectiveCamera) ProjectionMatrix() Matrix {
+	return NewMatrixPerspective(c.FieldOfView, cameraAspect(c.Aspect), c.Near, c.Far)
+}
+
+//ViewProjectionMatrix gets the projection matrix multiplied by the view matrix
+func (c *PerspectiveCamera) ViewProjectionMatrix() Matrix {
+	return c.ProjectionMatrix().Multiply(c.ViewMatrix())
+}
+
+//ScreenPointToRay gets the ray that goes from the camera through the point on the screen, in pixels. Use it with InputHandler.GetMousePosition to pick objects.
+func (c *PerspectiveCamera) ScreenPointToRay(point Vector2) Ray {
+	return screenPointToRay(c.ViewProjectionMatrix(), point)
+}
+
+//Frustum gets the planes of the volume the camera can see
+func (c *PerspectiveCamera) Frustum() Frustum {
+	return NewFrustumMatrix(c.ViewProjectionMatrix())
+}
+
+//OrthographicCamera is a camera where things appear the same size no matter how far away they are
+type OrthographicCamera struct {
+	CameraTransform
+	Size   float32 //Size is half of the height the camera can see in world units
+	Aspect float32 //Aspect is the width divided by the height. If 0, the aspect of the screen is used so the camera keeps up with resizes.
+	Near   float32 //Near is the closest distance the camera can see
+	Far    float32 //Far is the furthest distance the camera can see
+}
+
+//NewOrthographicCamera creates a new orthographic camera at the origin. The size is half of the height it can see.
+func NewOrthographicCamera(size, near, far float32) *OrthographicCamera {
+	return &OrthographicCamera{
+		CameraTransform: CameraTransform{Rotation: NewQuaternionIdentity()},
+		Size:            size,
+		Near:            near,
+		Far:             far,
+	}
+}
+
+//ProjectionMatrix gets the orthographic projection of the camera
+func (c *OrthographicCamera) ProjectionMatrix() Matrix {
+	width := c.Size * cameraAspect(c.Aspect)
+	return NewMatrixOrtho(-width, width, -c.Size, c.Size, c.Near, c.Far)
+}
+
+//ViewProjectionMatrix gets the projection matrix multiplied by the view matrix
+func (c *OrthographicCamera) ViewProjectionMatrix() Matrix {
+	return c.ProjectionMatrix().Multiply(c.ViewMatrix())
+}
+
+//ScreenPointToRay gets the ray that goes from the camera through the point on the screen, in pixels. Use it with InputHandler.GetMousePosition to pick objects.
+func (c *OrthographicCamera) ScreenPointToRay(point Vector2) Ray {
+	return screenPointToRay(c.ViewProjectionMatrix(), point)
+}
+
+//Frustum gets the planes of the volume the camera can see
+func (c *OrthographicCamera) Frustum() Frustum {
+	return NewFrustumMatrix(c.ViewProjectionMatrix())
+}
+
+//cameraAspect gets the aspect, falling back to the aspect of the screen
+func cameraAspect(aspect float32) float32 {
+	if aspect != 0 {
+		return aspect
+	}
+	width, height := screenSize()
+	return width / height
+}
+
+//screenSize gets the size of the screen in pixels. It is never 0, so it is safe to divide by.
+func screenSize() (float32, float32) {
+	if current == nil || current.width <= 0 || current.height <= 0 {
+		return 1, 1
+	}
+	return float32(current.width), float32(current.height)
+}
+
+//screenPointToRay unprojects the screen point onto the near and far planes of the view projection
+func screenPointToRay(viewProjection Matrix, point Vector2) Ray {
+	width, height := screenSize()
+
+	//Screen pixels go down from the top left, while clip space goes up from the center
+	x := point.X/width*2 - 1
+	y := 1 - point.Y/height*2
+
+	inverse := viewProjection.Invert()
+	near := unproject(inverse, Vector3{x, y, -1})
+	far := unproject(inverse, Vector3{x, y, 1})
+	return Ray{Origin: near, Direction: far.Subtract(near).Normalize()}
+}
+
+//unproject transforms the point by the matrix, including the perspective divide
+func unproject(m Matrix, v Vector3) Vector3 {
+	w := m.M3*v.X + m.M7*v.Y + m.M11*v.Z + m.M15
+	result := v.Transform(m)
+	if w != 0 {
+		result = result.Divide(w)
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package noodle
+
+import "math"
+
+//Camera2D looks at a 2D world that is drawn with the SpriteRenderer. World units are pixels when the zoom is 1,
+// and like the screen, Y goes down.
+type Camera2D struct {
+	Position Vector2 //Position is the point in the world at the center of the screen
+	Rotation float32 //Rotation of the camera in degrees
+	Zoom     float32 //Zoom is how many pixels a world unit takes up. 2 makes everything twice as big. 0 is treated as 1.
+
+	//Bounds is the area of the world the camera is kept inside of by Clamp. If it has no size, the camera is not clamped.
+	Bounds Rectangle
+}
+
+//NewCamera2D creates a new camera looking at the position
+func NewCamera2D(position Vector2) *Camera2D {
+	return &Camera2D{Position: position, Zoom: 1}
+}
+
+//zoom gets the zoom to draw with, so a zero value camera is not scaled down to nothing
+func (c *Camera2D) zoom() float32 {
+	if c.Zoom == 0 {
+		return 1
+	}
+	return c.Zoom
+}
+
+//ScreenMatrix gets the matrix that draws in screen pixels, with 0, 0 at the top left. Pass it to SpriteRenderer.Begin to draw without a camera.
+func ScreenMatrix() Matrix {
+	width, height := screenSize()
+	return NewMatrixOrtho(0, width, height, 0, -1, 1)
+}
+
+//ViewMatrix gets the matrix that transforms from world space into screen pixels
+func (c *Camera2D) ViewMatrix() Matrix {
+	width, height := screenSize()
+	rot := float64(c.Rotation * Deg2Rad)
+	cos := float32(math.Cos(rot)) * c.zoom()
+	sin := float32(math.Sin(rot)) * c.zoom()
+
+	//Move the position to the origin, rotate the opposite way to the camera, zoom, then move to the center of the screen
+	matrix := NewMatrixIdentity()
+	matrix.M0, matrix.M1 = cos, -sin
+	matrix.M4, matrix.M5 = sin, cos
+	matrix.M12 = width/2 - (cos*c.Position.X + sin*c.Position.Y)
+	matrix.M13 = height/2 - (-sin*c.Position.X + cos*c.Position.Y)
+	return matrix
+}
+
+//Matrix gets the matrix that transforms from world space into clip space. Pass it to SpriteRenderer.Begin.
+func (c *Camera2D) Matrix() Matrix {
+	return ScreenMatrix().Multiply(c.ViewMatrix())
+}
+
+//WorldToScreen converts a point in the world into screen pixels
+func (c *Camera2D) WorldToScreen(point Vector2) Vector2 {
+	result := point.ToVector3().Transform(c.ViewMatrix())
+	return Vector2{result.X, result.Y}
+}
+
+//ScreenToWorld converts a point in screen pixels, such as the mouse position, into the world
+func (c *Camera2D) ScreenToWorld(point Vector2) Vector2 {
+	result := point.ToVector3().Transform(c.ViewMatrix().Invert())
+	return Vector2{result.X, result.Y}
+}
+
+//ZoomAt changes the zoom while keeping the world point under the screen point in the same place, such as zooming towards the mouse
+func (c *Camera2D) ZoomAt(point Vector2, zoom float32) {
+	before := c.ScreenToWorld(point)
+	c.Zoom = zoom
+	after := c.ScreenToWorld(point)
+	c.Position = c.Position.Add(before.Subtract(after))
+	c.Clamp()
+}
+
+//Follow moves the camera towards the target. The higher the smoothing, the faster it catches up, and the movement is the same no matter the frame rate.
+// A smoothing of 0 snaps straight to the target.
+func (c *Camera2D) Follow(target Vector2, smoothing, dt float32) {
+	if smoothing <= 0 {
+		c.Position = target
+	} else {
+		amount := 1 - float32(math.Exp(float64(-smoothing*dt)))
+		c.Position = c.Position.Lerp(target, amount)
+	}
+	c.Clamp()
+}
+
+//VisibleArea gets the area of the world that is on the screen, ignoring rotation
+func (c *Camera2D) VisibleArea() Rectangle {
+	width, height := screenSize()
+	size := Vector2{width / c.zoom(), height / c.zoom()}
+	return NewRectangleFromPositionSize(c.Position.Subtract(size.Scale(0.5)), size)
+}
+
+//Clamp moves the camera so the visible area stays inside the Bounds. If the bounds are smaller than the visible area, the camera is centered on them.
+// Follow and ZoomAt clamp for you; call it after setting the Position yourself.
+func (c *Camera2D) Clamp() {
+	if c.Bounds.Width <= 0 || c.Bounds.Height <= 0 {
+		return
+	}
+
+	visible := c.VisibleArea()
+	c.Position.X = clampAxis(c.Position.X, visible.Width/2, c.Bounds.X, c.Bounds.Width)
+	c.Position.Y = clampAxis(c.Position.Y, visible.Height/2, c.Bounds.Y, c.Bounds.Height)
+}
+
+//clampAxis keeps the center so that half the view either side stays within the bounds
+func clampAxis(center, half, min, size float32) float32 {
+	if half*2 >= size {
+		return min + size/2
+	}
+	return Clamp32(center, min+half, min+size-half)
+}
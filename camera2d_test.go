@@ -0,0 +1,140 @@
+package noodle
+
+import "testing"
+
+//vector2Near checks if the vectors are within the tolerance
+func vector2Near(a, b Vector2, tolerance float32) bool {
+	return Abs32(a.X-b.X) <= tolerance && Abs32(a.Y-b.Y) <= tolerance
+}
+
+func TestCamera2DZeroValue(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := &Camera2D{Position: Vector2{100, 50}}
+
+	area := camera.VisibleArea()
+	if area.Width != 800 || area.Height != 600 {
+		t.Errorf("expected the zero zoom to show the whole screen, got %v", area)
+	}
+
+	point := Vector2{30, 40}
+	if back := camera.ScreenToWorld(camera.WorldToScreen(point)); Abs32(back.X-point.X) > 1e-3 || Abs32(back.Y-point.Y) > 1e-3 {
+		t.Errorf("expected the view matrix to invert, %v came back as %v", point, back)
+	}
+	if center := camera.WorldToScreen(camera.Position); center != (Vector2{400, 300}) {
+		t.Errorf("expected the position to be at the center of the screen, got %v", center)
+	}
+}
+
+func TestCamera2DRotation(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewCamera2D(Vector2{})
+	camera.Rotation = 90
+
+	//Turning the camera clockwise turns the world the other way, so a point to its right appears above the center
+	if screen := camera.WorldToScreen(Vector2{10, 0}); !vector2Near(screen, Vector2{400, 290}, 1e-3) {
+		t.Errorf("expected (400, 290), got %v", screen)
+	}
+	if screen := camera.WorldToScreen(Vector2{0, 10}); !vector2Near(screen, Vector2{410, 300}, 1e-3) {
+		t.Errorf("expected (410, 300), got %v", screen)
+	}
+}
+
+func TestCamera2DRoundTrips(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewCamera2D(Vector2{-40, 90})
+	camera.Rotation = 30
+	camera.Zoom = 2.5
+
+	for _, point := range []Vector2{{}, {123, -45}, {-800, 600}} {
+		if back := camera.ScreenToWorld(camera.WorldToScreen(point)); !vector2Near(back, point, 1e-3) {
+			t.Errorf("the world point %v came back as %v", point, back)
+		}
+		if back := camera.WorldToScreen(camera.ScreenToWorld(point)); !vector2Near(back, point, 1e-3) {
+			t.Errorf("the screen point %v came back as %v", point, back)
+		}
+	}
+	if center := camera.WorldToScreen(camera.Position); !vector2Near(center, Vector2{400, 300}, 1e-3) {
+		t.Errorf("expected the position to be at the center of the screen, got %v", center)
+	}
+
+	//A camera at the center of the screen with no zoom or rotation draws in screen pixels
+	if !matrixNear(NewCamera2D(Vector2{400, 300}).Matrix(), ScreenMatrix(), 1e-5) {
+		t.Error("expected an unmoved camera to match the screen matrix")
+	}
+}
+
+func TestCamera2DZoomAt(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewCamera2D(Vector2{-40, 90})
+	camera.Rotation = 30
+
+	//The point under the mouse stays there while everything else zooms around it
+	mouse := Vector2{100, 50}
+	world := camera.ScreenToWorld(mouse)
+	for _, zoom := range []float32{5, 0.25, 1} {
+		camera.ZoomAt(mouse, zoom)
+		if camera.Zoom != zoom {
+			t.Fatalf("expected a zoom of %v, got %v", zoom, camera.Zoom)
+		}
+		if after := camera.ScreenToWorld(mouse); !vector2Near(after, world, 1e-3) {
+			t.Errorf("zooming to %v moved the point under the mouse from %v to %v", zoom, world, after)
+		}
+	}
+}
+
+func TestCamera2DClamp(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewCamera2D(Vector2{})
+	camera.Bounds = NewRectangle(0, 0, 2000, 1000)
+
+	//The 800x600 view is pushed back inside the bounds
+	camera.Clamp()
+	if camera.Position != (Vector2{400, 300}) {
+		t.Errorf("expected (400, 300), got %v", camera.Position)
+	}
+	camera.Position = Vector2{5000, 5000}
+	camera.Clamp()
+	if camera.Position != (Vector2{1600, 700}) {
+		t.Errorf("expected (1600, 700), got %v", camera.Position)
+	}
+
+	//Zoomed out, the view is bigger than the bounds on Y, so it is centered on that axis
+	camera.Zoom = 0.5
+	camera.Clamp()
+	if camera.Position != (Vector2{1200, 500}) {
+		t.Errorf("expected (1200, 500), got %v", camera.Position)
+	}
+
+	//Without bounds the camera can go anywhere
+	camera.Bounds = Rectangle{}
+	camera.Position = Vector2{-5000, 5000}
+	camera.Clamp()
+	if camera.Position != (Vector2{-5000, 5000}) {
+		t.Errorf("expected the camera to stay put without bounds, got %v", camera.Position)
+	}
+}
+
+func TestCamera2DFollow(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	target := Vector2{100, 0}
+
+	//Following for a second gets to the same place no matter the frame rate
+	fast, slow := NewCamera2D(Vector2{}), NewCamera2D(Vector2{})
+	for i := 0; i < 60; i++ {
+		fast.Follow(target, 5, 1.0/60)
+	}
+	for i := 0; i < 20; i++ {
+		slow.Follow(target, 5, 1.0/20)
+	}
+	if !vector2Near(fast.Position, slow.Position, 1e-2) || fast.Position.X < 99 || fast.Position.X >= 100 {
+		t.Errorf("expected both to nearly catch up, got %v and %v", fast.Position, slow.Position)
+	}
+
+	//No smoothing snaps to the target, but still stays in the bounds
+	camera := NewCamera2D(Vector2{})
+	camera.Bounds = NewRectangle(0, 0, 1000, 1000)
+	camera.Follow(Vector2{700, 100}, 0, 1.0/60)
+	if camera.Position != (Vector2{600, 300}) {
+		t.Errorf("expected the camera to snap to the target and be clamped to (600, 300), got %v", camera.Position)
+	}
+}
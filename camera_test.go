@@ -0,0 +1,116 @@
+package noodle
+
+import "testing"
+
+func TestCameraLookAt(t *testing.T) {
+	camera := NewPerspectiveCamera(60, 0.1, 100)
+	camera.Position = Vector3{3, 3, 3}
+	camera.LookAt(Vector3{}, Vector3{0, 1, 0})
+
+	if expected := NewMatrixLookAt(camera.Position, Vector3{}, Vector3{0, 1, 0}); !matrixNear(camera.ViewMatrix(), expected, 1e-4) {
+		t.Errorf("expected the view %v, got %v", expected, camera.ViewMatrix())
+	}
+	if forward := camera.Forward(); !vectorNear(forward, Vector3{-1, -1, -1}.Normalize(), 1e-5) {
+		t.Errorf("expected the camera to face the origin, got %v", forward)
+	}
+	if camera.Up().Y <= 0 || Abs32(camera.Right().Y) > 1e-5 {
+		t.Errorf("expected the camera to be upright, got up %v and right %v", camera.Up(), camera.Right())
+	}
+}
+
+func TestCameraLookAtAlongUp(t *testing.T) {
+	//Looking straight down or up makes the direction parallel to up, so another axis must be used
+	for _, position := range []Vector3{{0, 10, 0}, {0, -10, 0}} {
+		camera := NewPerspectiveCamera(60, 0.1, 100)
+		camera.Position = position
+		camera.LookAt(Vector3{}, Vector3{0, 1, 0})
+
+		forward, up := camera.Forward(), camera.Up()
+		if !vectorNear(forward, position.Negate().Normalize(), 1e-5) {
+			t.Errorf("%v: expected the camera to face the origin, got %v", position, forward)
+		}
+		if !vectorNear(up, up.Normalize(), 1e-5) || Abs32(up.DotProduct(forward)) > 1e-5 {
+			t.Errorf("%v: expected up to be at right angles to forward, got %v", position, up)
+		}
+	}
+
+	//Looking at its own position leaves the camera as it was
+	camera := NewPerspectiveCamera(60, 0.1, 100)
+	camera.LookAt(Vector3{}, Vector3{0, 1, 0})
+	if camera.Rotation != NewQuaternionIdentity() {
+		t.Errorf("expected the rotation to be kept, got %v", camera.Rotation)
+	}
+}
+
+func TestPerspectiveCameraScreenPointToRay(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewPerspectiveCamera(90, 1, 100)
+	camera.Position = Vector3{1, 2, 3}
+
+	//The centre of the 800x600 screen goes straight down the middle, starting on the near plane
+	ray := camera.ScreenPointToRay(Vector2{400, 300})
+	if !vectorNear(ray.Direction, camera.Forward(), 1e-4) || !vectorNear(ray.Origin, Vector3{1, 2, 2}, 1e-4) {
+		t.Errorf("expected the centre ray to start at (1, 2, 2) and go forward, got %+v", ray)
+	}
+
+	//The top left corner is up and to the left. The vertical field of view is 90, so it is 45 degrees up.
+	corner := camera.ScreenPointToRay(Vector2{0, 0})
+	if corner.Direction.DotProduct(camera.Up()) <= 0 || corner.Direction.DotProduct(camera.Right()) >= 0 {
+		t.Errorf("expected the corner ray to go up and left, got %v", corner.Direction)
+	}
+	if expected := (Vector3{-4.0 / 3, 1, -1}).Normalize(); !vectorNear(corner.Direction, expected, 1e-4) {
+		t.Errorf("expected the corner ray to go along %v, got %v", expected, corner.Direction)
+	}
+}
+
+func TestOrthographicCameraScreenPointToRay(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewOrthographicCamera(5, 0.1, 100)
+	camera.Position = Vector3{0, 0, 10}
+
+	//Every ray goes forward, starting from where the point is on the near plane
+	for _, point := range []Vector2{{400, 300}, {800, 0}, {0, 600}} {
+		ray := camera.ScreenPointToRay(point)
+		x := (point.X/400 - 1) * 5 * 800 / 600
+		y := (1 - point.Y/300) * 5
+		if !vectorNear(ray.Direction, Vector3{0, 0, -1}, 1e-4) || !vectorNear(ray.Origin, Vector3{x, y, 9.9}, 1e-3) {
+			t.Errorf("%v: expected a ray forward from (%v, %v, 9.9), got %+v", point, x, y, ray)
+		}
+	}
+}
+
+func TestCameraFrustum(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	camera := NewPerspectiveCamera(90, 1, 100)
+	camera.Position = Vector3{0, 0, 10}
+	frustum := camera.Frustum()
+
+	points := []struct {
+		point  Vector3
+		inside bool
+	}{
+		{Vector3{0, 0, 0}, true},
+		{Vector3{5, 5, 0}, true},
+		{Vector3{0, 0, 9.5}, false},
+		{Vector3{0, 0, 20}, false},
+		{Vector3{0, 0, -95}, false},
+		{Vector3{0, 12, 0}, false},
+		{Vector3{-16, 0, 0}, false},
+	}
+	for _, p := range points {
+		if frustum.ContainsPoint(p.point) != p.inside {
+			t.Errorf("%v: expected inside to be %v", p.point, p.inside)
+		}
+	}
+
+	//A sphere that is outside but reaches over the edge is still seen
+	if !frustum.IntersectsSphere(Vector3{0, 11, 0}, 2) || frustum.IntersectsSphere(Vector3{0, 13, 0}, 2) {
+		t.Error("expected only the sphere that reaches into the frustum to intersect it")
+	}
+	if !frustum.IntersectsSphere(Vector3{0, 0, 12}, 3) || frustum.IntersectsSphere(Vector3{0, 0, 12}, 1) {
+		t.Error("expected only the sphere that reaches past the near plane to intersect it")
+	}
+	if !frustum.IntersectsBox(Vector3{-1, -1, -1}, Vector3{1, 1, 1}) || frustum.IntersectsBox(Vector3{20, 20, -1}, Vector3{30, 30, 1}) {
+		t.Error("expected only the box at the origin to intersect")
+	}
+}
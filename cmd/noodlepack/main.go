@@ -0,0 +1,63 @@
+//Command noodlepack builds a noodle pack from a directory, so an application can download all of its resources in one request.
+//
+//Usage:
+//
+//	noodlepack [-o resources.npak] [-compress=false] [-list] directory
+//
+//Every file in the directory is added, named by its path relative to the directory. Hidden files are skipped.
+// Load the pack at runtime with pack.Load.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lachee/noodle/pack"
+)
+
+func main() {
+	output := flag.String("o", "resources.npak", "the pack file to write")
+	compress := flag.Bool("compress", true, "compress files with DEFLATE when it makes them smaller")
+	list := flag.Bool("list", false, "list the files as they are added")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: noodlepack [flags] directory")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	builder := pack.NewBuilder()
+	if err := builder.AddDir(flag.Arg(0), *compress); err != nil {
+		log.Fatalln("Failed to read the directory", err)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		log.Fatalln("Failed to create the pack", err)
+	}
+
+	size, err := builder.WriteTo(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatalln("Failed to write the pack", err)
+	}
+
+	if *list {
+		for _, entry := range builder.Entries() {
+			compressed := ""
+			if entry.Compressed {
+				compressed = " (compressed)"
+			}
+			fmt.Printf("%s\t%d bytes%s\n", entry.Name, entry.Size, compressed)
+		}
+	}
+	fmt.Printf("Wrote %d files to %s, %d bytes\n", len(builder.Entries()), *output, size)
+}
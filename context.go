@@ -0,0 +1,498 @@
+package noodle
+
+import (
+	"errors"
+	"log"
+	"math"
+)
+
+const (
+	//DefaultMaxFixedSteps is the most fixed updates run in a single frame if no limit is given to SetFixedTimestep
+	DefaultMaxFixedSteps = 5
+
+	//maxFixedFrameTime is the longest frame in seconds the fixed updates will try to catch up on
+	maxFixedFrameTime = 0.25
+)
+
+//Context is a single canvas running an Application. It owns the platform, the GL context, the input and the frame timing,
+// so several can exist on the same page.
+//
+//While a context is running its Application, it is the current context: the package level GL, Input(), Width() and similar functions refer to it.
+type Context struct {
+	platform Platform
+	gl       GLContext
+	input    *InputHandler
+	app      Application
+
+	width  int
+	height int
+
+	frameTime  float64
+	deltaTime  float64
+	frameCount int64
+
+	canvasWidth  int //canvasWidth is the CSS width given to SetCanvasSize before the platform was setup
+	canvasHeight int //canvasHeight is the CSS height given to SetCanvasSize before the platform was setup
+
+	fixedStep     float64 //fixedStep is the seconds between fixed updates, or 0 if they are disabled
+	maxFixedSteps int     //maxFixedSteps is the most fixed updates that will run in a single frame
+	accumulator   float64 //accumulator is the time that has not yet been consumed by fixed updates
+	alpha         float64 //alpha is how far between the last and next fixed update the frame is
+
+	resources []GLResource
+	lost      bool
+	paused    bool
+	focused   bool
+	stopped   bool
+	resumed   bool //resumed causes the next frame to have no delta, so time spent paused is skipped
+
+	unlitShader *Shader //unlitShader is shared by every material made with NewUnlitMaterial
+
+	awaiter chan int
+}
+
+//GLResource is something that owns objects in the GL context. When the context is lost every object is destroyed,
+// so resources are tracked by the context and asked to recreate themselves once it is restored.
+type GLResource interface {
+	//Restore recreates the GL objects of the resource in the restored context
+	Restore() error
+	//Release deletes the GL objects of the resource
+	Release()
+}
+
+//defaults is the context the package level functions refer to before any context has started. The canvas size and
+// fixed timestep given to it are the defaults of every new context, so they can be set before Run.
+var defaults = &Context{input: newInput()}
+
+//current is the context that is currently running
+var current = defaults
+
+//NewContext creates a new context that will run on the platform
+func NewContext(platform Platform) *Context {
+	return &Context{
+		platform:      platform,
+		input:         newInput(),
+		awaiter:       make(chan int, 1),
+		canvasWidth:   defaults.canvasWidth,
+		canvasHeight:  defaults.canvasHeight,
+		fixedStep:     defaults.fixedStep,
+		maxFixedSteps: defaults.maxFixedSteps,
+	}
+}
+
+//track adds the resource to the current context and returns it. Resources keep the context that created them, so they
+// are untracked from and deleted in the right GL context even if they are released while another canvas is current.
+func track(resource GLResource) *Context {
+	current.Track(resource)
+	return current
+}
+
+//CurrentContext gets the context that is currently running
+func CurrentContext() *Context { return current }
+
+//makeCurrent sets this context as the one the package level functions refer to
+func (ctx *Context) makeCurrent() {
+	current = ctx
+	GL = ctx.gl
+}
+
+//Platform gets the platform the context runs on
+func (ctx *Context) Platform() Platform { return ctx.platform }
+
+//GL gets the GL context of the canvas
+func (ctx *Context) GL() GLContext { return ctx.gl }
+
+//Input gets the input handler of the canvas
+func (ctx *Context) Input() *InputHandler { return ctx.input }
+
+//Application gets the application being run
+func (ctx *Context) Application() Application { return ctx.app }
+
+//Width gets the width of the canvas in device pixels
+func (ctx *Context) Width() int { return ctx.width }
+
+//Height gets the height of the canvas in device pixels
+func (ctx *Context) Height() int { return ctx.height }
+
+//PixelRatio gets how many device pixels there are for each CSS pixel
+func (ctx *Context) PixelRatio() float64 {
+	if ctx.platform == nil {
+		return 1
+	}
+	return ctx.platform.PixelRatio()
+}
+
+//FrameTime gets the time the last frame was rendered
+func (ctx *Context) FrameTime() float64 { return ctx.frameTime }
+
+//DeltaTime gets the difference in time between the last frame and the current one.
+func (ctx *Context) DeltaTime() float64 { return ctx.deltaTime }
+
+//FrameCount gets the current frame
+func (ctx *Context) FrameCount() int64 { return ctx.frameCount }
+
+//SetFixedTimestep enables fixed updates at the tick rate, in updates per second. A rate of 0 disables them.
+// To avoid a spiral of death, at most maxSteps updates are run each frame and any time beyond that is dropped.
+// If maxSteps is 0, the DefaultMaxFixedSteps is used.
+func (ctx *Context) SetFixedTimestep(tickRate float64, maxSteps int) {
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxFixedSteps
+	}
+
+	ctx.fixedStep = 0
+	if tickRate > 0 {
+		ctx.fixedStep = 1 / tickRate
+	}
+	ctx.maxFixedSteps = maxSteps
+	ctx.accumulator = 0
+	ctx.alpha = 0
+}
+
+//FixedStep gets the seconds between each fixed update, or 0 if they are disabled
+func (ctx *Context) FixedStep() float64 { return ctx.fixedStep }
+
+//Alpha gets how far the current frame is between the last fixed update and the next, from 0 to 1.
+// Render uses it to interpolate between the previous and current state. It is always 0 when fixed updates are disabled.
+func (ctx *Context) Alpha() float64 { return ctx.alpha }
+
+//IsPaused checks if the application is paused, such as when the page is hidden. No frames run while paused.
+func (ctx *Context) IsPaused() bool { return ctx.paused }
+
+//IsFocused checks if the canvas has focus
+func (ctx *Context) IsFocused() bool { return ctx.focused }
+
+//IsLost checks if the GL context has been lost. The application loop is paused until it is restored.
+func (ctx *Context) IsLost() bool { return ctx.lost }
+
+//Track adds the resource to the list that will be restored if the GL context is lost, and released when the application exits.
+// Resources are restored in the order they are tracked, and released in reverse.
+// Textures, Shaders and the renderers track themselves when created.
+func (ctx *Context) Track(resource GLResource) {
+	ctx.resources = append(ctx.resources, resource)
+}
+
+//Untrack removes the resource so it will no longer be restored
+func (ctx *Context) Untrack(resource GLResource) {
+	for i, r := range ctx.resources {
+		if r == resource {
+			ctx.resources = append(ctx.resources[:i], ctx.resources[i+1:]...)
+			return
+		}
+	}
+}
+
+//Run setups the platform and runs the application on it. It is blocking and returns an exit code if Exit() is ever called.
+func (ctx *Context) Run(application Application) int {
+	if err := ctx.Start(application); err != nil {
+		log.Println(err)
+		return 0
+	}
+	return ctx.Wait()
+}
+
+//Start setups the platform and starts the application on it, but does not wait for it to exit.
+// Frames are driven entirely by the platform, so this is how a HeadlessPlatform is used, and how multiple contexts are run together.
+func (ctx *Context) Start(application Application) error {
+	ctx.app = application
+
+	//Create a new GL instance
+	context, err := ctx.platform.Setup()
+	if err != nil {
+		return err
+	}
+	ctx.gl = context
+	if ctx.canvasWidth > 0 && ctx.canvasHeight > 0 {
+		ctx.platform.SetSize(ctx.canvasWidth, ctx.canvasHeight)
+	}
+	ctx.width, ctx.height = ctx.platform.Size()
+	previous, previousGL := current, GL
+	ctx.makeCurrent()
+
+	//Setup the animation frame. If the application fails to start, whatever it created is released and the context that
+	// was current before is current again.
+	if !ctx.app.Start() {
+		ctx.platform.Release()
+		ctx.releaseResources()
+		ctx.gl = nil
+		current, GL = previous, previousGL
+		return errors.New("failed to start the application")
+	}
+
+	//Begin rendering
+	ctx.platform.Listen(ctx.onEvent)
+	ctx.gl.Viewport(0, 0, ctx.width, ctx.height)
+	ctx.platform.RequestFrame(ctx.onFrame)
+	return nil
+}
+
+//Wait blocks until Exit() is called and returns the exit code
+func (ctx *Context) Wait() int {
+	return <-ctx.awaiter
+}
+
+//SetCanvasSize the size of the canvas in CSS pixels. The canvas will no longer follow the size of the window.
+// If the context has not started, the size is set once it does.
+func (ctx *Context) SetCanvasSize(w, h int) {
+	if ctx.gl == nil {
+		ctx.canvasWidth, ctx.canvasHeight = w, h
+		return
+	}
+	ctx.platform.SetSize(w, h)
+	ctx.onResize()
+}
+
+//RequestRedraw requests for a new animation frame. It does nothing if the context has not started.
+func (ctx *Context) RequestRedraw() {
+	if ctx.gl == nil {
+		return
+	}
+	ctx.platform.RequestFrame(ctx.onFrame)
+}
+
+//Exit stops the application. The Application is told if it is a Stopper, then the platform's listeners and frames are released
+// along with every tracked GL resource, and finally Wait is unblocked. It does nothing if the context has not started.
+func (ctx *Context) Exit() {
+	if ctx.stopped || ctx.gl == nil {
+		return
+	}
+
+	ctx.stopped = true
+	ctx.makeCurrent()
+	if stopper, ok := ctx.app.(Stopper); ok {
+		stopper.Stop()
+	}
+
+	ctx.platform.Release()
+	ctx.releaseResources()
+
+	select {
+	case ctx.awaiter <- 1:
+	default:
+	}
+}
+
+//onEvent passes the platform events to the input handler
+func (ctx *Context) onEvent(evt Event) {
+	switch evt.Type {
+	case EventMouseMove:
+		ctx.input.setMousePosition(evt.X, evt.Y)
+	case EventMouseDown:
+		ctx.input.setMouseDown(evt.Button)
+	case EventMouseUp:
+		ctx.input.setMouseUp(evt.Button)
+	case EventMouseScroll:
+		ctx.input.setMouseScroll(evt.Delta)
+	case EventKeyDown:
+		ctx.input.setKeyDown(int(evt.Key))
+	case EventKeyUp:
+		ctx.input.setKeyUp(int(evt.Key))
+	case EventContextLost:
+		ctx.onContextLost()
+	case EventContextRestored:
+		ctx.onContextRestored()
+	case EventResize:
+		ctx.onResize()
+	case EventPause:
+		ctx.onPause()
+	case EventResume:
+		ctx.onResume()
+	case EventFocus:
+		ctx.onFocus(true)
+	case EventBlur:
+		ctx.onFocus(false)
+	}
+}
+
+//onPause halts the loop until the application is resumed
+func (ctx *Context) onPause() {
+	if ctx.paused || ctx.stopped {
+		return
+	}
+
+	ctx.paused = true
+	if pauser, ok := ctx.app.(Pauser); ok {
+		ctx.makeCurrent()
+		pauser.Pause()
+	}
+}
+
+//onResume restarts the loop
+func (ctx *Context) onResume() {
+	if !ctx.paused || ctx.stopped {
+		return
+	}
+
+	ctx.paused = false
+	ctx.resumed = true
+	if pauser, ok := ctx.app.(Pauser); ok {
+		ctx.makeCurrent()
+		pauser.Resume()
+	}
+
+	if !ctx.lost {
+		ctx.RequestRedraw()
+	}
+}
+
+//onFocus tells the application the canvas gained or lost focus
+func (ctx *Context) onFocus(focused bool) {
+	if ctx.focused == focused || ctx.stopped {
+		return
+	}
+
+	ctx.focused = focused
+	if focuser, ok := ctx.app.(Focuser); ok {
+		ctx.makeCurrent()
+		if focused {
+			focuser.Focus()
+		} else {
+			focuser.Blur()
+		}
+	}
+}
+
+//releaseResources deletes every tracked resource in the reverse order they were tracked
+func (ctx *Context) releaseResources() {
+	resources := ctx.resources
+	ctx.resources = nil
+	if ctx.gl == nil || ctx.lost {
+		return
+	}
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		resources[i].Release()
+	}
+}
+
+//onResize reads the new size of the canvas, updates the viewport and tells the application
+func (ctx *Context) onResize() {
+	width, height := ctx.platform.Size()
+	if width == ctx.width && height == ctx.height {
+		return
+	}
+
+	ctx.width, ctx.height = width, height
+	ctx.makeCurrent()
+	if !ctx.lost {
+		ctx.gl.Viewport(0, 0, ctx.width, ctx.height)
+	}
+
+	if resizer, ok := ctx.app.(Resizer); ok {
+		resizer.Resized(ctx.width, ctx.height)
+	}
+}
+
+//onContextLost pauses the loop until the context is restored
+func (ctx *Context) onContextLost() {
+	if ctx.lost {
+		return
+	}
+
+	log.Println("GL context lost")
+	ctx.lost = true
+	if restorer, ok := ctx.app.(ContextRestorer); ok {
+		ctx.makeCurrent()
+		restorer.ContextLost()
+	}
+}
+
+//onContextRestored recreates all the tracked resources, then resumes the loop
+func (ctx *Context) onContextRestored() {
+	if !ctx.lost {
+		return
+	}
+
+	log.Println("GL context restored")
+	ctx.lost = false
+	ctx.makeCurrent()
+	ctx.gl.Viewport(0, 0, ctx.width, ctx.height)
+
+	//Restore a copy, as resources may track new resources while restoring
+	resources := append([]GLResource(nil), ctx.resources...)
+	for _, resource := range resources {
+		if err := resource.Restore(); err != nil {
+			log.Println("failed to restore resource", err)
+		}
+	}
+
+	if restorer, ok := ctx.app.(ContextRestorer); ok {
+		restorer.ContextRestored()
+	}
+
+	ctx.resumed = true
+	if !ctx.paused {
+		ctx.RequestRedraw()
+	}
+}
+
+//fixedUpdate consumes the frame time in fixed steps, calling FixedUpdate for each, then updates the alpha
+func (ctx *Context) fixedUpdate() {
+
+	//Clamp the delta, so a long pause such as a hidden tab does not have to be caught up on
+	delta := ctx.deltaTime
+	if delta > maxFixedFrameTime {
+		delta = maxFixedFrameTime
+	} else if delta < 0 {
+		delta = 0
+	}
+	ctx.accumulator += delta
+
+	updater, _ := ctx.app.(FixedUpdater)
+	steps := 0
+	for ctx.accumulator >= ctx.fixedStep {
+		if steps >= ctx.maxFixedSteps {
+			//We are too far behind, so drop the whole steps we can't afford to run
+			ctx.accumulator = math.Mod(ctx.accumulator, ctx.fixedStep)
+			break
+		}
+
+		if updater != nil {
+			updater.FixedUpdate(float32(ctx.fixedStep))
+		}
+		ctx.accumulator -= ctx.fixedStep
+		steps++
+	}
+
+	ctx.alpha = ctx.accumulator / ctx.fixedStep
+}
+
+//onFrame callback for animations
+func (ctx *Context) onFrame(timestamp float64) {
+
+	//Nothing can be drawn without a context, so the loop stops here until it is restored or resumed
+	if ctx.lost || ctx.paused || ctx.stopped {
+		return
+	}
+
+	ctx.makeCurrent()
+
+	//Setupt he time, skipping any time spent paused
+	time := timestamp / 1000
+	if ctx.resumed {
+		ctx.frameTime = time
+		ctx.resumed = false
+	}
+	ctx.deltaTime = time - ctx.frameTime
+	ctx.frameTime = time
+	ctx.frameCount++
+
+	//Update the input
+	ctx.input.update()
+
+	//Run the fixed updates, then call update on the Application
+	if ctx.fixedStep > 0 {
+		ctx.fixedUpdate()
+	}
+	ctx.app.Update(float32(ctx.deltaTime))
+	if ctx.stopped {
+		return
+	}
+
+	//Render everything
+	ctx.app.Render()
+
+	//If we need to draw again, then do so
+	if AlwaysDraw {
+		ctx.RequestRedraw()
+	}
+}
@@ -0,0 +1,108 @@
+package noodle
+
+import "testing"
+
+func TestContextDefaultsBeforeRun(t *testing.T) {
+	previous, saved := current, *defaults
+	current = defaults
+	defer func() {
+		current = previous
+		defaults.canvasWidth, defaults.canvasHeight = saved.canvasWidth, saved.canvasHeight
+		defaults.fixedStep, defaults.maxFixedSteps = saved.fixedStep, saved.maxFixedSteps
+	}()
+
+	//None of these have a platform to use yet
+	Exit()
+	RequestRedraw()
+	SetCanvasSize(320, 240)
+	SetFixedTimestep(50, 2)
+	if PixelRatio() != 1 {
+		t.Error("expected a pixel ratio of 1 without a platform")
+	}
+
+	ctx, err := StartPlatform(&headlessApp{}, NewHeadlessPlatform(800, 600))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Exit()
+	if ctx.Width() != 320 || ctx.Height() != 240 {
+		t.Errorf("expected the canvas to start at 320x240, got %dx%d", ctx.Width(), ctx.Height())
+	}
+	if ctx.FixedStep() != 1.0/50 {
+		t.Errorf("expected a fixed step of %v, got %v", 1.0/50, ctx.FixedStep())
+	}
+}
+
+func TestResourcesReleaseFromTheirContext(t *testing.T) {
+	first := NewHeadlessPlatform(100, 100)
+	firstCtx, err := StartPlatform(&headlessApp{}, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer firstCtx.Exit()
+	texture := newTestTexture(t, 2, 2)
+	shader, err := LoadShader(unlitVertCode, unlitFragCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Starting the second canvas makes it current, so the resources are released while it is
+	second := NewHeadlessPlatform(100, 100)
+	secondCtx, err := StartPlatform(&headlessApp{}, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondCtx.Exit()
+
+	first.GL().ResetCalls()
+	second.GL().ResetCalls()
+	texture.Release()
+	shader.Release()
+
+	if len(callsNamed(first.GL(), "deleteTexture")) != 1 || len(callsNamed(first.GL(), "deleteProgram")) != 1 {
+		t.Error("the resources were not deleted from the context that created them")
+	}
+	if len(second.GL().Calls()) != 0 {
+		t.Errorf("the current context was used instead: %v", second.GL().Calls())
+	}
+	if len(firstCtx.resources) != 0 {
+		t.Errorf("%d resources are still tracked", len(firstCtx.resources))
+	}
+}
+
+//failingApp fails to start after calling start
+type failingApp struct {
+	headlessApp
+	start func()
+}
+
+func (app *failingApp) Start() bool {
+	app.start()
+	return false
+}
+
+func TestContextFailedStartReleasesPlatform(t *testing.T) {
+	first := startHeadless(t, &headlessApp{})
+	previous := CurrentContext()
+
+	platform := NewHeadlessPlatform(100, 100)
+	app := &failingApp{start: func() { newTestTexture(t, 2, 2) }}
+	ctx, err := StartPlatform(app, platform)
+	if err == nil {
+		t.Fatal("expected the start to fail")
+	}
+
+	if len(callsNamed(platform.GL(), "deleteTexture")) != 1 || len(ctx.resources) != 0 {
+		t.Error("the texture created by Start was not released")
+	}
+	if CurrentContext() != previous || GL != first.GL() {
+		t.Error("the context that was current before was not restored")
+	}
+
+	//Nothing is left listening, and exiting does nothing as it never started
+	platform.Dispatch(Event{Type: EventResize})
+	if platform.Step(16) {
+		t.Error("a frame was still requested")
+	}
+	ctx.Exit()
+}
@@ -1,11 +1,13 @@
 package noodle
 
 import (
+	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"net/http"
 )
 
-//DownloadFile fetches a URL and return the bytes
+//DownloadFile fetches a URL and return the bytes. It always uses HTTP; use ReadFile to read through Files.
 func DownloadFile(url string) ([]byte, error) {
 
 	//Download the URL
@@ -15,6 +17,14 @@ func DownloadFile(url string) ([]byte, error) {
 	}
 	defer response.Body.Close()
 
+	//Error pages are not the file that was asked for
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to download %s: %w", url, fs.ErrNotExist)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download %s: %s", url, response.Status)
+	}
+
 	//Read the contents and return it
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
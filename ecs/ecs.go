@@ -0,0 +1,150 @@
+//Package ecs is an entity component system for noodle. Entities are ids, components are plain structs stored by their type,
+// and systems process every entity that has the components they ask for.
+package ecs
+
+import (
+	"reflect"
+	"sort"
+)
+
+//Entity is a handle to a thing in the world. It has no data of its own, only the components that are added to it.
+type Entity uint32
+
+//ComponentType identifies the type of a component. Components are stored by the type of the value added, so a *Velocity and a Velocity are different types.
+type ComponentType = reflect.Type
+
+//TypeOf gets the ComponentType of the component
+func TypeOf(component interface{}) ComponentType {
+	return reflect.TypeOf(component)
+}
+
+//World holds the entities, their components and the systems that process them
+type World struct {
+	nextEntity Entity
+	alive      map[Entity]bool
+	storages   map[ComponentType]*Storage
+
+	systems     []systemEntry
+	systemCount int
+}
+
+//NewWorld creates a new empty world
+func NewWorld() *World {
+	return &World{
+		alive:    make(map[Entity]bool),
+		storages: make(map[ComponentType]*Storage),
+	}
+}
+
+//Create creates a new entity with the components. Entities are never reused, so a newer entity always has a larger id.
+func (w *World) Create(components ...interface{}) Entity {
+	w.nextEntity++
+	entity := w.nextEntity
+	w.alive[entity] = true
+
+	for _, component := range components {
+		w.Add(entity, component)
+	}
+	return entity
+}
+
+//Destroy removes the entity and all its components from the world
+func (w *World) Destroy(entity Entity) {
+	if !w.alive[entity] {
+		return
+	}
+
+	delete(w.alive, entity)
+	for _, storage := range w.storages {
+		storage.remove(entity)
+	}
+}
+
+//Alive checks if the entity exists in the world
+func (w *World) Alive(entity Entity) bool { return w.alive[entity] }
+
+//Count gets the number of entities in the world
+func (w *World) Count() int { return len(w.alive) }
+
+//Storage gets the storage of the component type, creating it if it does not yet exist
+func (w *World) Storage(componentType ComponentType) *Storage {
+	storage, ok := w.storages[componentType]
+	if !ok {
+		storage = newStorage(componentType)
+		w.storages[componentType] = storage
+	}
+	return storage
+}
+
+//Add adds the component to the entity, replacing any component of the same type it already has.
+// Components should be pointers, so systems can modify them in place.
+func (w *World) Add(entity Entity, component interface{}) {
+	if !w.alive[entity] {
+		return
+	}
+	w.Storage(TypeOf(component)).set(entity, component)
+}
+
+//Remove removes the component type from the entity
+func (w *World) Remove(entity Entity, componentType ComponentType) {
+	if storage, ok := w.storages[componentType]; ok {
+		storage.remove(entity)
+	}
+}
+
+//Get gets the component of the type from the entity, or nil if it does not have one
+func (w *World) Get(entity Entity, componentType ComponentType) interface{} {
+	storage, ok := w.storages[componentType]
+	if !ok {
+		return nil
+	}
+	return storage.Get(entity)
+}
+
+//Has checks if the entity has all the component types
+func (w *World) Has(entity Entity, componentTypes ...ComponentType) bool {
+	if !w.alive[entity] {
+		return false
+	}
+
+	for _, componentType := range componentTypes {
+		storage, ok := w.storages[componentType]
+		if !ok || !storage.Has(entity) {
+			return false
+		}
+	}
+	return true
+}
+
+//Query gets every entity that has all the component types, ordered by when they were created
+func (w *World) Query(componentTypes ...ComponentType) []Entity {
+	var entities []Entity
+	if len(componentTypes) == 0 {
+		entities = make([]Entity, 0, len(w.alive))
+		for entity := range w.alive {
+			entities = append(entities, entity)
+		}
+	} else {
+
+		//Start with the smallest storage, then filter out anything that is missing one of the others
+		var smallest *Storage
+		for _, componentType := range componentTypes {
+			storage, ok := w.storages[componentType]
+			if !ok {
+				return nil
+			}
+			if smallest == nil || storage.Len() < smallest.Len() {
+				smallest = storage
+			}
+		}
+
+		for _, entity := range smallest.entities {
+			if w.Has(entity, componentTypes...) {
+				entities = append(entities, entity)
+			}
+		}
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return entities[i] < entities[j] })
+	return entities
+}
@@ -0,0 +1,155 @@
+package ecs
+
+import (
+	"fmt"
+	"testing"
+)
+
+type position struct{ X, Y float32 }
+type velocity struct{ X, Y float32 }
+type health struct{ Value int }
+
+var (
+	positionType = TypeOf((*position)(nil))
+	velocityType = TypeOf((*velocity)(nil))
+	healthType   = TypeOf((*health)(nil))
+)
+
+//expectEntities checks the entities are exactly the expected ones, in order
+func expectEntities(t *testing.T, entities []Entity, expected ...Entity) {
+	t.Helper()
+	if len(entities) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, entities)
+	}
+	for i := range expected {
+		if entities[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, entities)
+		}
+	}
+}
+
+func TestWorldCreateAndDestroy(t *testing.T) {
+	world := NewWorld()
+	first := world.Create(&position{X: 1}, &velocity{})
+	second := world.Create()
+	if first == second || second < first || world.Count() != 2 {
+		t.Fatalf("expected 2 new entities, got %d and %d", first, second)
+	}
+	if !world.Has(first, positionType, velocityType) || world.Has(second, positionType) {
+		t.Error("the components were not added to the right entity")
+	}
+
+	world.Destroy(first)
+	world.Destroy(first)
+	if world.Alive(first) || world.Count() != 1 || world.Get(first, positionType) != nil {
+		t.Error("the entity or its components were not destroyed")
+	}
+	if world.Storage(positionType).Len() != 0 || world.Storage(velocityType).Len() != 0 {
+		t.Error("the storages still hold the components of the destroyed entity")
+	}
+
+	//Ids are not reused, and destroyed entities cannot be given components
+	world.Add(first, &health{})
+	if third := world.Create(); third <= second {
+		t.Errorf("expected a new id after %d, got %d", second, third)
+	}
+	if world.Storage(healthType).Len() != 0 {
+		t.Error("a component was added to a destroyed entity")
+	}
+}
+
+func TestStorageRemoveMovesTheLastComponent(t *testing.T) {
+	world := NewWorld()
+	components := []*position{{X: 1}, {X: 2}, {X: 3}}
+	var entities []Entity
+	for _, component := range components {
+		entities = append(entities, world.Create(component))
+	}
+
+	//Removing the first moves the last into its place, which must still be found by its entity
+	storage := world.Storage(positionType)
+	world.Remove(entities[0], positionType)
+	if storage.Len() != 2 || storage.Has(entities[0]) {
+		t.Fatalf("expected the component to be removed, %d are left", storage.Len())
+	}
+	if storage.entities[0] != entities[2] || storage.components[0] != components[2] {
+		t.Fatalf("expected the last component to be moved to the front, got %v", storage.entities)
+	}
+	for i := 1; i < 3; i++ {
+		if storage.Get(entities[i]) != components[i] {
+			t.Errorf("entity %d: expected %v, got %v", entities[i], components[i], storage.Get(entities[i]))
+		}
+	}
+
+	//Removing the last leaves the others where they are
+	world.Remove(entities[1], positionType)
+	world.Remove(entities[1], positionType)
+	if storage.Len() != 1 || storage.Get(entities[2]) != components[2] {
+		t.Errorf("expected only entity %d to be left, got %v", entities[2], storage.entities)
+	}
+}
+
+func TestWorldQuery(t *testing.T) {
+	world := NewWorld()
+	var entities []Entity
+	for i := 0; i < 5; i++ {
+		entities = append(entities, world.Create(&position{}))
+	}
+	world.Add(entities[4], &velocity{})
+	world.Add(entities[1], &velocity{})
+	world.Add(entities[3], &velocity{})
+
+	//Removing from the front reorders the storage, but the query is still in the order the entities were created
+	world.Remove(entities[0], positionType)
+	expectEntities(t, world.Query(positionType), entities[1], entities[2], entities[3], entities[4])
+
+	//The velocities are the smallest storage, so the positions filter them, whichever order the types are given in
+	world.Remove(entities[3], positionType)
+	expectEntities(t, world.Query(positionType, velocityType), entities[1], entities[4])
+	expectEntities(t, world.Query(velocityType, positionType), entities[1], entities[4])
+
+	world.Destroy(entities[4])
+	expectEntities(t, world.Query(velocityType), entities[1], entities[3])
+	expectEntities(t, world.Query(), entities[:4]...)
+	if found := world.Query(positionType, healthType); found != nil {
+		t.Errorf("expected nothing to have a component that was never added, got %v", found)
+	}
+}
+
+//orderSystem records when it updates
+type orderSystem struct {
+	name   string
+	events *[]string
+}
+
+func (s *orderSystem) Components() []ComponentType { return nil }
+func (s *orderSystem) Update(world *World, entities []Entity, dt float32) {
+	*s.events = append(*s.events, s.name)
+}
+
+func TestAddSystemOrdersByPriority(t *testing.T) {
+	var events []string
+	world := NewWorld()
+	physics := &orderSystem{"physics", &events}
+	world.AddSystem(&orderSystem{"render", &events}, 10)
+	world.AddSystem(&orderSystem{"input", &events}, -1)
+	world.AddSystem(physics, 0)
+	world.AddSystem(&orderSystem{"collisions", &events}, 0)
+	world.AddSystem(&orderSystem{"animation", &events}, 10)
+
+	//Systems with the same priority run in the order they were added
+	world.Update(0.1)
+	expected := "[input physics collisions render animation]"
+	if got := fmt.Sprint(events); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	events = nil
+	world.RemoveSystem(physics)
+	world.AddSystem(physics, 0)
+	world.Update(0.1)
+	expected = "[input collisions physics render animation]"
+	if got := fmt.Sprint(events); got != expected {
+		t.Errorf("after adding physics again, expected %s, got %s", expected, got)
+	}
+}
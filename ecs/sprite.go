@@ -0,0 +1,93 @@
+package ecs
+
+import (
+	"sort"
+
+	"github.com/lachee/noodle"
+)
+
+var (
+	//TransformType is the component type of a *noodle.Transform2D, which positions an entity in 2D
+	TransformType = TypeOf((*noodle.Transform2D)(nil))
+	//SpriteType is the component type of a *Sprite
+	SpriteType = TypeOf((*Sprite)(nil))
+)
+
+//Sprite is a component that draws a UVTile at the entity's transform
+type Sprite struct {
+	Tile   noodle.UVTile  //Tile is what is drawn
+	Origin noodle.Vector2 //Origin is the point the sprite is drawn around, from 0 to 1
+	Color  noodle.Color   //Color tints the sprite
+	Layer  int            //Layer orders the sprites, with lower layers drawn first
+}
+
+//NewSprite creates a new white sprite component that is drawn around its centre
+func NewSprite(tile noodle.UVTile) *Sprite {
+	return &Sprite{
+		Tile:   tile,
+		Origin: noodle.Vector2{X: 0.5, Y: 0.5},
+		Color:  noodle.White,
+	}
+}
+
+//SpriteSystem draws every entity with a Transform2D and Sprite to a SpriteRenderer
+type SpriteSystem struct {
+	Renderer *noodle.SpriteRenderer
+	Camera   *noodle.Camera2D //Camera the sprites are drawn with. If nil, they are drawn in screen pixels.
+}
+
+//NewSpriteSystem creates a new sprite system that draws to the renderer
+func NewSpriteSystem(renderer *noodle.SpriteRenderer) *SpriteSystem {
+	return &SpriteSystem{Renderer: renderer}
+}
+
+//Components are a Transform2D and a Sprite
+func (s *SpriteSystem) Components() []ComponentType {
+	return []ComponentType{TransformType, SpriteType}
+}
+
+//Render draws the sprites in order of their layer, then the order the entities were created. The errors of the
+// renderer are returned.
+func (s *SpriteSystem) Render(world *World, entities []Entity) error {
+	sprites := make([]*Sprite, len(entities))
+	for i, entity := range entities {
+		sprites[i] = world.Get(entity, SpriteType).(*Sprite)
+	}
+
+	sort.Stable(spritesByLayer{entities, sprites})
+
+	matrix := noodle.ScreenMatrix()
+	if s.Camera != nil {
+		matrix = s.Camera.Matrix()
+	}
+
+	if err := s.Renderer.Begin(matrix); err != nil {
+		return err
+	}
+	for i, entity := range entities {
+		sprite := sprites[i]
+		if sprite.Tile == nil {
+			continue
+		}
+
+		transform := world.Get(entity, TransformType).(*noodle.Transform2D)
+		if err := s.Renderer.Draw(sprite.Tile, sprite.Origin, *transform, sprite.Color); err != nil {
+			s.Renderer.End()
+			return err
+		}
+	}
+	return s.Renderer.End()
+}
+
+//spritesByLayer sorts the entities and their sprites together
+type spritesByLayer struct {
+	entities []Entity
+	sprites  []*Sprite
+}
+
+func (s spritesByLayer) Len() int           { return len(s.entities) }
+func (s spritesByLayer) Less(i, j int) bool { return s.sprites[i].Layer < s.sprites[j].Layer }
+func (s spritesByLayer) Swap(i, j int) {
+	s.entities[i], s.entities[j] = s.entities[j], s.entities[i]
+	s.sprites[i], s.sprites[j] = s.sprites[j], s.sprites[i]
+}
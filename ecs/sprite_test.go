@@ -0,0 +1,46 @@
+package ecs
+
+import (
+	"image"
+	"testing"
+
+	"github.com/lachee/noodle"
+)
+
+//emptyApp is an Application that does nothing, so the test can draw between frames
+type emptyApp struct{}
+
+func (emptyApp) Start() bool    { return true }
+func (emptyApp) Update(float32) {}
+func (emptyApp) Render()        {}
+
+func TestSpriteSystemReturnsRendererErrors(t *testing.T) {
+	ctx, err := noodle.StartPlatform(emptyApp{}, noodle.NewHeadlessPlatform(100, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Exit()
+
+	img, err := noodle.LoadImageRGBA(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderer, err := noodle.NewSpriteRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	world := NewWorld()
+	world.AddSystem(NewSpriteSystem(renderer), 0)
+	transform := noodle.NewTransform2D(noodle.Vector2{}, 0, noodle.Vector2{X: 1, Y: 1})
+	world.Create(&transform, NewSprite(img.CreateTexture()))
+	if err := world.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	//A renderer that was left drawing cannot begin again
+	renderer.Begin(noodle.ScreenMatrix())
+	if err := world.Render(); err != noodle.ErrAlreadyDrawing {
+		t.Fatalf("expected ErrAlreadyDrawing, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package ecs
+
+import "fmt"
+
+//Storage holds every component of a single type, packed together so they can be iterated quickly
+type Storage struct {
+	componentType ComponentType
+	components    []interface{}
+	entities      []Entity
+	index         map[Entity]int
+}
+
+//newStorage creates a new storage for the component type
+func newStorage(componentType ComponentType) *Storage {
+	return &Storage{
+		componentType: componentType,
+		index:         make(map[Entity]int),
+	}
+}
+
+//Type gets the type of component the storage holds
+func (s *Storage) Type() ComponentType { return s.componentType }
+
+//Len gets the number of components in the storage
+func (s *Storage) Len() int { return len(s.components) }
+
+//Has checks if the entity has a component in the storage
+func (s *Storage) Has(entity Entity) bool {
+	_, ok := s.index[entity]
+	return ok
+}
+
+//Get gets the component of the entity, or nil if it does not have one
+func (s *Storage) Get(entity Entity) interface{} {
+	i, ok := s.index[entity]
+	if !ok {
+		return nil
+	}
+	return s.components[i]
+}
+
+//set stores the component against the entity. It panics if the component is not the type of the storage.
+func (s *Storage) set(entity Entity, component interface{}) {
+	if TypeOf(component) != s.componentType {
+		panic(fmt.Sprintf("ecs: cannot store a %v in the storage for %v", TypeOf(component), s.componentType))
+	}
+
+	if i, ok := s.index[entity]; ok {
+		s.components[i] = component
+		return
+	}
+
+	s.index[entity] = len(s.components)
+	s.components = append(s.components, component)
+	s.entities = append(s.entities, entity)
+}
+
+//remove removes the component of the entity, moving the last component into its place
+func (s *Storage) remove(entity Entity) {
+	i, ok := s.index[entity]
+	if !ok {
+		return
+	}
+
+	last := len(s.components) - 1
+	s.components[i] = s.components[last]
+	s.entities[i] = s.entities[last]
+	s.index[s.entities[i]] = i
+
+	s.components[last] = nil
+	s.components = s.components[:last]
+	s.entities = s.entities[:last]
+	delete(s.index, entity)
+}
@@ -0,0 +1,100 @@
+package ecs
+
+import "sort"
+
+//System processes every entity that has the components it asks for. A system should implement at least one of
+// Updater, FixedUpdater or Renderer.
+type System interface {
+	//Components are the component types an entity must have to be processed by the system
+	Components() []ComponentType
+}
+
+//Updater is a System that runs every frame
+type Updater interface {
+	System
+	Update(world *World, entities []Entity, dt float32)
+}
+
+//FixedUpdater is a System that runs at the fixed timestep
+type FixedUpdater interface {
+	System
+	FixedUpdate(world *World, entities []Entity, dt float32)
+}
+
+//Renderer is a System that draws the entities. It returns an error if they could not be drawn, such as when a
+// noodle renderer has not been ended.
+type Renderer interface {
+	System
+	Render(world *World, entities []Entity) error
+}
+
+//systemEntry is a system with its ordering
+type systemEntry struct {
+	system   System
+	priority int
+	order    int
+}
+
+//AddSystem adds the system to the world. Systems with a lower priority run first, and systems with the same priority
+// run in the order they were added, so the order is always the same.
+func (w *World) AddSystem(system System, priority int) {
+	w.systemCount++
+	w.systems = append(w.systems, systemEntry{system, priority, w.systemCount})
+	sort.SliceStable(w.systems, func(i, j int) bool {
+		if w.systems[i].priority != w.systems[j].priority {
+			return w.systems[i].priority < w.systems[j].priority
+		}
+		return w.systems[i].order < w.systems[j].order
+	})
+}
+
+//RemoveSystem removes the system from the world
+func (w *World) RemoveSystem(system System) {
+	for i, entry := range w.systems {
+		if entry.system == system {
+			w.systems = append(w.systems[:i], w.systems[i+1:]...)
+			return
+		}
+	}
+}
+
+//Systems gets the systems in the order they run
+func (w *World) Systems() []System {
+	systems := make([]System, len(w.systems))
+	for i, entry := range w.systems {
+		systems[i] = entry.system
+	}
+	return systems
+}
+
+//Update runs every Updater system on the entities it queries
+func (w *World) Update(dt float32) {
+	for _, system := range w.Systems() {
+		if updater, ok := system.(Updater); ok {
+			updater.Update(w, w.Query(system.Components()...), dt)
+		}
+	}
+}
+
+//FixedUpdate runs every FixedUpdater system on the entities it queries
+func (w *World) FixedUpdate(dt float32) {
+	for _, system := range w.Systems() {
+		if updater, ok := system.(FixedUpdater); ok {
+			updater.FixedUpdate(w, w.Query(system.Components()...), dt)
+		}
+	}
+}
+
+//Render runs every Renderer system on the entities it queries. Every system runs even if an earlier one fails, then the
+// first error is returned.
+func (w *World) Render() error {
+	var first error
+	for _, system := range w.Systems() {
+		if renderer, ok := system.(Renderer); ok {
+			if err := renderer.Render(w, w.Query(system.Components()...)); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
@@ -0,0 +1,22 @@
+package noodle
+
+import "errors"
+
+var (
+	//ErrNotDrawing is returned when a renderer is drawn to or ended before Begin
+	ErrNotDrawing = errors.New("the renderer has not begun drawing")
+	//ErrAlreadyDrawing is returned when a renderer is begun again before End
+	ErrAlreadyDrawing = errors.New("the renderer is already drawing")
+	//ErrNoSprite is returned when the UIRenderer draws before a sprite has been set
+	ErrNoSprite = errors.New("no sprite has been set")
+	//ErrShaderCompile is matched by the *ShaderCompileError of a shader that failed to compile or link
+	ErrShaderCompile = errors.New("shader failed to compile")
+)
+
+//fail returns the error, or panics with it if noodle is Strict
+func fail(err error) error {
+	if Strict {
+		panic(err)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package noodle
+
+import (
+	"errors"
+	"testing"
+)
+
+//expectPanic calls the function, returning what it panicked with
+func expectPanic(t *testing.T, call func()) (recovered interface{}) {
+	t.Helper()
+	defer func() { recovered = recover() }()
+	call()
+	t.Error("expected a panic")
+	return nil
+}
+
+func TestFailReturnsErrors(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	renderer, err := NewSpriteRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := renderer.End(); err != ErrNotDrawing {
+		t.Errorf("expected ErrNotDrawing, got %v", err)
+	}
+
+	_, err = LoadShader("", unlitFragCode)
+	var compile *ShaderCompileError
+	if !errors.Is(err, ErrShaderCompile) || !errors.As(err, &compile) || compile.Stage != ShaderStageVertex {
+		t.Errorf("expected a vertex *ShaderCompileError, got %v", err)
+	}
+}
+
+func TestStrictPanics(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	renderer, err := NewSpriteRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	if recovered := expectPanic(t, func() { renderer.End() }); recovered != ErrNotDrawing {
+		t.Errorf("expected a panic with ErrNotDrawing, got %v", recovered)
+	}
+	recovered := expectPanic(t, func() { LoadShader(unlitVertCode, "") })
+	if compile, ok := recovered.(*ShaderCompileError); !ok || compile.Stage != ShaderStageFragment {
+		t.Errorf("expected a panic with a fragment *ShaderCompileError, got %v", recovered)
+	}
+}
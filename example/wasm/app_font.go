@@ -17,6 +17,7 @@ type FontApp struct {
 	boxSprite      *n.SliceSprite
 	spriteRenderer *n.SpriteRenderer
 	uiRenderer     *n.UIRenderer
+	camera         *n.Camera2D
 	previewRune    rune
 }
 
@@ -24,11 +25,11 @@ type FontApp struct {
 func (app *FontApp) Start() bool {
 
 	//Load the TTF font
-	fontData, err := n.DownloadFile("/resources/fonts/BalsamiqSans-Regular.ttf")
-	//fontData, err := n.DownloadFile("/resources/fonts/ShareTechMono-Regular.ttf")
-	//fontData, err := n.DownloadFile("/resources/fonts/LobsterTwo-Regular.ttf")
-	//fontData, err := n.DownloadFile("/resources/fonts/Notable-Regular.ttf")
-	//fontData, err := n.DownloadFile("/resources/fonts/luxirr.ttf")
+	fontData, err := n.ReadFile("/resources/fonts/BalsamiqSans-Regular.ttf")
+	//fontData, err := n.ReadFile("/resources/fonts/ShareTechMono-Regular.ttf")
+	//fontData, err := n.ReadFile("/resources/fonts/LobsterTwo-Regular.ttf")
+	//fontData, err := n.ReadFile("/resources/fonts/Notable-Regular.ttf")
+	//fontData, err := n.ReadFile("/resources/fonts/luxirr.ttf")
 	if err != nil {
 		log.Fatalln("Failed to download font", err)
 		return false
@@ -52,8 +53,19 @@ func (app *FontApp) Start() bool {
 	app.font = app.fontBitmap
 
 	//Load the renderers
-	app.uiRenderer = n.NewUIRenderer()
-	app.spriteRenderer = n.NewSpriteRenderer()
+	app.uiRenderer, err = n.NewUIRenderer()
+	if err != nil {
+		log.Fatalln("Failed to create the UI renderer", err)
+		return false
+	}
+	app.spriteRenderer, err = n.NewSpriteRenderer()
+	if err != nil {
+		log.Fatalln("Failed to create the sprite renderer", err)
+		return false
+	}
+
+	//Start with the top left of the world in the top left of the screen
+	app.camera = n.NewCamera2D(Vector2{float32(n.Width()) / 2, float32(n.Height()) / 2})
 	cursor, _ := n.LoadImage("resources/cursors.svg")
 	cursorTexture := cursor.CreateTexture()
 	app.cursor = n.NewSprite(cursorTexture, Rectangle{0, 0, float32(cursorTexture.Width()) / 8.0, float32(cursorTexture.Height()) / 8.0})
@@ -67,7 +79,6 @@ func (app *FontApp) Start() bool {
 	boxTexture := boxImage.CreateTexture()
 	tileSize := float32(boxTexture.Width()) / 1.0
 	app.boxSprite = n.NewSliceSprite(boxTexture, Rectangle{tileSize * 0, 0, tileSize, float32(boxTexture.Height())}, Vector2{10, 10})
-	app.uiRenderer = n.NewUIRenderer()
 
 	return true
 }
@@ -94,20 +105,21 @@ func (app *FontApp) Update(dt float32) {
 	}
 
 	//Camera Control
-	axis := n.Input().GetAxis2D(n.KeyArrowLeft, n.KeyArrowRight, n.KeyArrowDown, n.KeyArrowUp).Scale(1 / dt)
-	app.spriteRenderer.Camera = app.spriteRenderer.Camera.Add(axis.Scale(-0.05 * app.spriteRenderer.Zoom))
+	axis := n.Input().GetAxis2D(n.KeyArrowLeft, n.KeyArrowRight, n.KeyArrowUp, n.KeyArrowDown)
+	app.camera.Position = app.camera.Position.Add(axis.Scale(500 * dt / app.camera.Zoom))
 
+	//Zoom towards the mouse
 	scroll := n.Input().GetMouseScroll()
 	if scroll > 0 {
-		app.spriteRenderer.Zoom += 0.01 * dt
+		app.camera.ZoomAt(n.Input().GetMousePosition(), app.camera.Zoom*1.1)
 	}
 	if scroll < 0 {
-		app.spriteRenderer.Zoom -= 0.01 * dt
+		app.camera.ZoomAt(n.Input().GetMousePosition(), app.camera.Zoom/1.1)
 	}
 
 	if n.Input().GetButtonDown(1) {
-		app.spriteRenderer.Zoom = 7.5
-		app.spriteRenderer.Camera.X = -1.9
+		app.camera.Zoom = 1
+		app.camera.Position = Vector2{float32(n.Width()) / 2, float32(n.Height()) / 2}
 	}
 
 	//index = int(math.Abs(math.Mod(float64(index-1), float64(len(app.font.Set)))))
@@ -133,7 +145,7 @@ func (app *FontApp) Render() {
 
 func (app *FontApp) renderFont() {
 
-	app.spriteRenderer.Begin()
+	app.spriteRenderer.Begin(app.camera.Matrix())
 	//Draw the atlas
 	//atlasTransform := n.NewTransform2D(Vector2{0, 0}, 0, Vector2{1, 1})
 
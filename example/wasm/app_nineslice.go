@@ -91,7 +91,7 @@ func (app *NineSliceApp) Start() bool {
 
 	// == Create Matrixes
 	// Generate and apply projection matrix
-	app.projMatrix = n.NewMatrixPerspective(45.0, float64(n.Width())/float64(n.Height()), 1, 100.0)
+	app.projMatrix = n.NewMatrixPerspective(45.0, float32(n.Width())/float32(n.Height()), 1, 100.0)
 	n.GL.UniformMatrix4fv(app.uProjMatrixLoc, app.projMatrix)
 
 	// Generate and apply view matrix
@@ -148,3 +148,33 @@ var ninePlaneUV = []Vector2{
 	{1, 1}, {1, 0},
 }
 var ninePlaneTris = []uint16{0, 1, 2, 0, 2, 3}
+
+var rotCubeVerts = []Vector3{
+	Vector3{-1, -1, -1}, Vector3{1, -1, -1}, Vector3{1, 1, -1}, Vector3{-1, 1, -1},
+	Vector3{-1, -1, 1}, Vector3{1, -1, 1}, Vector3{1, 1, 1}, Vector3{-1, 1, 1},
+	Vector3{-1, -1, -1}, Vector3{-1, 1, -1}, Vector3{-1, 1, 1}, Vector3{-1, -1, 1},
+	Vector3{1, -1, -1}, Vector3{1, 1, -1}, Vector3{1, 1, 1}, Vector3{1, -1, 1},
+	Vector3{-1, -1, -1}, Vector3{-1, -1, 1}, Vector3{1, -1, 1}, Vector3{1, -1, -1},
+	Vector3{-1, 1, -1}, Vector3{-1, 1, 1}, Vector3{1, 1, 1}, Vector3{1, 1, -1},
+}
+var rotCubeUV = []Vector2{
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
+}
+var rotCubeColours = []float32{
+	5, 3, 7, 5, 3, 7, 5, 3, 7, 5, 3, 7,
+	1, 1, 3, 1, 1, 3, 1, 1, 3, 1, 1, 3,
+	0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1,
+	1, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0,
+	1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 0,
+	0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0,
+}
+var rotCubeTris = []uint16{
+	0, 1, 2, 0, 2, 3, 4, 5, 6, 4, 6, 7,
+	8, 9, 10, 8, 10, 11, 12, 13, 14, 12, 14, 15,
+	16, 17, 18, 16, 18, 19, 20, 21, 22, 20, 22, 23,
+}
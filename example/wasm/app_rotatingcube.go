@@ -6,26 +6,18 @@ import (
 	"log"
 
 	n "github.com/lachee/noodle"
+	"github.com/lachee/noodle/primitive"
 )
 
 //RotatingCubeApp shows off the 3D capabilities
 type RotatingCubeApp struct {
-	vertexBuffer n.WebGLBuffer
-	colorBuffer  n.WebGLBuffer
-	indexBuffer  n.WebGLBuffer
-	uvBuffer     n.WebGLBuffer
+	mesh     *n.Mesh
+	material *n.Material
+	renderer *n.MeshRenderer
+	camera   *n.PerspectiveCamera
 
-	shader *n.Shader
-
-	uProjMatrixLoc  n.WebGLUniformLocation
-	uViewMatrixLoc n.WebGLUniformLocation
-	uModelMatrixLoc n.WebGLUniformLocation
-	uSamplerLoc     n.WebGLUniformLocation
-
-	projMatrix  Matrix
-	viewMatrix  Matrix
-	modelMatrix Matrix
-	moveMatrix  Matrix
+	pivot *n.Node
+	cube  *n.Node
 
 	rotation float32
 	texture  *n.Texture
@@ -37,7 +29,6 @@ func (app *RotatingCubeApp) prepareImage() (*n.Image, error) {
 	const width = 255
 	const height = 255
 
-	
 	//Create the image
 	upLeft := image.Point{0, 0}
 	lowRight := image.Point{width, height}
@@ -58,13 +49,7 @@ func (app *RotatingCubeApp) prepareImage() (*n.Image, error) {
 //Start is called by the noodle engine when ready
 func (app *RotatingCubeApp) Start() bool {
 
-	// Create vertex buffer
-	app.vertexBuffer = n.GL.NewBuffer(n.GlArrayBuffer, rotCubeVerts, n.GlStaticDraw)
-	app.colorBuffer = n.GL.NewBuffer(n.GlArrayBuffer, rotCubeColours, n.GlStaticDraw)
-	app.indexBuffer = n.GL.NewBuffer(n.GlElementArrayBuffer, rotCubeTris, n.GlStaticDraw)
-	app.uvBuffer = n.GL.NewBuffer(n.GlArrayBuffer, rotCubeUV, n.GlStaticDraw)
-
-	// == Load the cube image and the shaders
+	// == Load the cube image and mesh
 	image, err := app.prepareImage()
 	if err != nil {
 		log.Fatalln("Failed to load image", err)
@@ -72,45 +57,34 @@ func (app *RotatingCubeApp) Start() bool {
 	}
 	app.texture = n.NewTexture(image)
 
-	//Load the cube shader
-	shader, err := n.LoadShaderFromURL("resources/shader/rotatingCube.vert", "resources/shader/rotatingCube.frag")
-	if err != nil {
-		log.Fatalln("Failed to load the shaders! ", err)
+	app.mesh = primitive.Cube(2, 1)
+	if err := app.mesh.Upload(); err != nil {
+		log.Fatalln("Failed to upload the mesh", err)
 		return false
 	}
-	app.shader = shader
-
-	// == Link the shaders up
-	// Associate attributes to vertex shader
-	app.uProjMatrixLoc = app.shader.GetUniformLocation("Pmatrix")
-	app.uViewMatrixLoc = app.shader.GetUniformLocation("Vmatrix")
-	app.uModelMatrixLoc = app.shader.GetUniformLocation("Mmatrix")
-	app.uSamplerLoc = app.shader.GetUniformLocation("uSampler")
 
-	app.shader.BindVertexData("position", n.GlArrayBuffer, app.vertexBuffer, 3, n.GlFloat, false, 0, 0)
-	app.shader.BindVertexData("color", n.GlArrayBuffer, app.colorBuffer, 3, n.GlFloat, false, 0, 0)
-	app.shader.BindVertexData("textureCoord", n.GlArrayBuffer, app.uvBuffer, 2, n.GlFloat, false, 0, 0)
-	app.shader.Use()
-
-	// == Set WeebGL properties
+	// == Create the material and renderer
+	app.material, err = n.NewUnlitMaterial(app.texture)
+	if err != nil {
+		log.Fatalln("Failed to create the material", err)
+		return false
+	}
+	app.renderer = n.NewMeshRenderer()
 	n.GL.ClearColor(0.5, 0.5, 0.5, 0.9)
 	n.GL.ClearDepth(1)
-	n.GL.Viewport(0, 0, n.Width(), n.Height())
-	n.GL.DepthFunc(n.GlLEqual)
-
-	// == Create Matrixes
-	// Generate and apply projection matrix
-	app.projMatrix = n.NewMatrixPerspective(45.0, float64(n.Width())/float64(n.Height()), 1, 100.0)
-	n.GL.UniformMatrix4fv(app.uProjMatrixLoc, app.projMatrix)
-
-	// Generate and apply view matrix
-	app.viewMatrix = n.NewMatrixLookAt(Vector3{3.0, 3.0, 3.0}, Vector3{0, 0, 0}, Vector3{0, 1, 0})
-	n.GL.UniformMatrix4fv(app.uViewMatrixLoc, app.viewMatrix)
-
-	//Update the texture shit
-	// Activate the text0, tell the texture to bind, then tell the
-	//  sampler that it should be on 0
-	app.texture.SetSampler(app.uSamplerLoc, 0)
+
+	// == Create the camera
+	// The aspect is left at 0 so it follows the canvas when it resizes
+	app.camera = n.NewPerspectiveCamera(45.0, 1, 100.0)
+	app.camera.Position = Vector3{3.0, 3.0, 3.0}
+	app.camera.LookAt(Vector3{0, 0, 0}, Vector3{0, 1, 0})
+
+	// == Create the scene graph
+	// The cube tumbles inside a pivot that is turned slightly
+	app.pivot = n.NewNode("pivot")
+	app.pivot.SetRotation(n.NewQuaternionMatrix(n.NewMatrixRotate(n.NewVector3Up(), 0.5)))
+	app.cube = n.NewNode("cube")
+	app.pivot.AddChild(app.cube)
 	return true
 }
 
@@ -118,54 +92,26 @@ func (app *RotatingCubeApp) Start() bool {
 func (app *RotatingCubeApp) Update(dt float32) {
 	app.rotation = app.rotation + dt/500
 
-	//Update the move matrix
-	movMatrix := n.NewMatrixRotate(n.NewVector3Up(), 0.5)
-	movMatrix = movMatrix.Multiply(n.NewMatrixRotate(n.NewVector3Forward(), 0.3*app.rotation))
-	movMatrix = movMatrix.Multiply(n.NewMatrixRotate(n.NewVector3Right(), 0.2*app.rotation))
-	app.moveMatrix = movMatrix
+	//Tumble the cube
+	tumble := n.NewMatrixRotate(n.NewVector3Forward(), 0.3*app.rotation)
+	tumble = tumble.Multiply(n.NewMatrixRotate(n.NewVector3Right(), 0.2*app.rotation))
+	app.cube.SetRotation(n.NewQuaternionMatrix(tumble))
+
+	//Pick the cube with the mouse
+	if n.Input().GetButtonDown(0) {
+		ray := app.camera.ScreenPointToRay(n.Input().GetMousePosition())
+		if distance, ok := ray.IntersectSphere(app.cube.WorldPosition(), 1.5); ok {
+			log.Println("Clicked the cube", distance, "units away")
+		}
+	}
 }
 
 //Render occurs when the screen needs updating
 func (app *RotatingCubeApp) Render() {
-
-	//Update matrix
-	n.GL.UniformMatrix4fv(app.uModelMatrixLoc, app.moveMatrix)
-
-	//Clear
-	n.GL.BindBuffer(n.GlElementArrayBuffer, app.indexBuffer)
-	n.GL.Enable(n.GlDepthTest)
 	n.GL.Clear(n.GlColorBufferBit | n.GlDepthBufferBit)
 
 	// Draw the cube
-	n.GL.DrawElements(n.GlTriangles, len(rotCubeTris), n.GlUnsignedShort, 0)
-}
-
-var rotCubeVerts = []Vector3{
-	Vector3{-1, -1, -1}, Vector3{1, -1, -1}, Vector3{1, 1, -1}, Vector3{-1, 1, -1},
-	Vector3{-1, -1, 1}, Vector3{1, -1, 1}, Vector3{1, 1, 1}, Vector3{-1, 1, 1},
-	Vector3{-1, -1, -1}, Vector3{-1, 1, -1}, Vector3{-1, 1, 1}, Vector3{-1, -1, 1},
-	Vector3{1, -1, -1}, Vector3{1, 1, -1}, Vector3{1, 1, 1}, Vector3{1, -1, 1},
-	Vector3{-1, -1, -1}, Vector3{-1, -1, 1}, Vector3{1, -1, 1}, Vector3{1, -1, -1},
-	Vector3{-1, 1, -1}, Vector3{-1, 1, 1}, Vector3{1, 1, 1}, Vector3{1, 1, -1},
-}
-var rotCubeUV = []Vector2{
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-	Vector2{0.0, 0.0}, Vector2{1.0, 0.0}, Vector2{1.0, 1.0}, Vector2{0.0, 1.0},
-}
-var rotCubeColours = []float32{
-	5, 3, 7, 5, 3, 7, 5, 3, 7, 5, 3, 7,
-	1, 1, 3, 1, 1, 3, 1, 1, 3, 1, 1, 3,
-	0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1,
-	1, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0,
-	1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 0,
-	0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0,
-}
-var rotCubeTris = []uint16{
-	0, 1, 2, 0, 2, 3, 4, 5, 6, 4, 6, 7,
-	8, 9, 10, 8, 10, 11, 12, 13, 14, 12, 14, 15,
-	16, 17, 18, 16, 18, 19, 20, 21, 22, 20, 22, 23,
+	app.renderer.Begin(app.camera)
+	app.renderer.Draw(app.mesh, app.material, app.cube.WorldMatrix())
+	app.renderer.End()
 }
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 
 	n "github.com/lachee/noodle"
+	"github.com/lachee/noodle/ecs"
 )
 
 //Noodle Type Aliases are defined in aliases.go
@@ -16,43 +17,58 @@ type SpriteApp struct {
 	sprite    *n.Sprite
 	texture   *n.Texture
 	batch     *n.SpriteRenderer
-	balls     []*Ball
+	world     *ecs.World
 	ballCount int
 }
 
-//Ball structure represents a sprite that will bounce around
+//Ball component makes an entity bounce around the screen
 type Ball struct {
-	sprite              *n.Sprite
-	transform           Transform2D
-	origin              Vector2
 	velocity            Vector2
 	angularVelocity     float32
 	angularVelocitySign float32
 }
 
-func (ball *Ball) update(dt float32) {
-	ball.transform.Position.X += ball.velocity.X * dt
-	ball.transform.Position.Y += -ball.velocity.Y * dt
+//ballType is the component type of a *Ball
+var ballType = ecs.TypeOf((*Ball)(nil))
 
-	if appSpritesAllowRotate {
-		ball.transform.Rotation += ball.angularVelocity * dt * ball.angularVelocitySign
-	}
+//BallSystem bounces the balls
+type BallSystem struct{}
 
-	if ball.transform.Position.X < 0 {
-		ball.velocity.X *= -1
-		ball.transform.Position.X = 0
-		ball.angularVelocitySign = 1
-	} else if ball.transform.Position.X > float32(n.Width()) {
-		ball.velocity.X *= -1
-		ball.transform.Position.X = float32(n.Width())
-		ball.angularVelocitySign = -1
-	}
+//Components are the transform, sprite and the ball itself
+func (s *BallSystem) Components() []ecs.ComponentType {
+	return []ecs.ComponentType{ecs.TransformType, ecs.SpriteType, ballType}
+}
+
+//Update moves every ball
+func (s *BallSystem) Update(world *ecs.World, entities []ecs.Entity, dt float32) {
+	for _, entity := range entities {
+		transform := world.Get(entity, ecs.TransformType).(*Transform2D)
+		sprite := world.Get(entity, ecs.SpriteType).(*ecs.Sprite)
+		ball := world.Get(entity, ballType).(*Ball)
+
+		transform.Position.X += ball.velocity.X * dt
+		transform.Position.Y += -ball.velocity.Y * dt
+
+		if appSpritesAllowRotate {
+			transform.Rotation += ball.angularVelocity * dt * ball.angularVelocitySign
+		}
+
+		if transform.Position.X < 0 {
+			ball.velocity.X *= -1
+			transform.Position.X = 0
+			ball.angularVelocitySign = 1
+		} else if transform.Position.X > float32(n.Width()) {
+			ball.velocity.X *= -1
+			transform.Position.X = float32(n.Width())
+			ball.angularVelocitySign = -1
+		}
 
-	ball.velocity.Y += -0.0005 * dt
-	if ball.transform.Position.Y > float32(n.Height()-ball.sprite.Height()) {
-		ball.velocity.Y *= -.90
-		ball.transform.Position.Y = float32(n.Height() - ball.sprite.Height())
-		ball.angularVelocity *= 0.85
+		ball.velocity.Y += -0.0005 * dt
+		if transform.Position.Y > float32(n.Height()-sprite.Tile.Height()) {
+			ball.velocity.Y *= -.90
+			transform.Position.Y = float32(n.Height() - sprite.Tile.Height())
+			ball.angularVelocity *= 0.85
+		}
 	}
 }
 
@@ -71,12 +87,21 @@ func (app *SpriteApp) Start() bool {
 	//Setup the texture
 	app.texture = image.CreateTexture()
 	app.sprite = n.NewSprite(app.texture, Rectangle{0, 0, float32(app.texture.Width()), float32(app.texture.Height())})
-	app.batch = n.NewSpriteRenderer()
+	app.batch, err = n.NewSpriteRenderer()
+	if err != nil {
+		log.Fatalln("Failed to create the sprite renderer", err)
+		return false
+	}
 
 	cursor, _ := n.LoadImage("resources/cursors.svg")
 	cursorTexture := cursor.CreateTexture()
 	app.cursor = n.NewSprite(cursorTexture, Rectangle{0, 0, float32(cursorTexture.Width()) / 8.0, float32(cursorTexture.Height()) / 8.0})
 
+	//Setup the world. The balls bounce first, then the sprites are drawn.
+	app.world = ecs.NewWorld()
+	app.world.AddSystem(&BallSystem{}, 0)
+	app.world.AddSystem(ecs.NewSpriteSystem(app.batch), 100)
+
 	return true
 }
 
@@ -85,18 +110,13 @@ func (app *SpriteApp) Update(dt float32) {
 	if n.Input().GetButton(0) {
 
 		mouse := n.Input().GetMousePosition()
-		t := n.NewTransform2D(mouse, 0, Vector2{1, 1})
-
 		for i := 0; i < 1; i++ {
-			ball := &Ball{
-				sprite:              app.sprite,
-				transform:           t,
-				origin:              Vector2{0.5, 0.5},
+			t := n.NewTransform2D(mouse, 0, Vector2{1, 1})
+			app.world.Create(&t, ecs.NewSprite(app.sprite), &Ball{
 				velocity:            Vector2{rand.Float32() * 0.5, 0},
 				angularVelocity:     rand.Float32(),
 				angularVelocitySign: 1,
-			}
-			app.balls = append(app.balls, ball)
+			})
 			app.ballCount++
 		}
 
@@ -104,9 +124,7 @@ func (app *SpriteApp) Update(dt float32) {
 	}
 
 	//update the balls
-	for _, ball := range app.balls {
-		ball.update(dt)
-	}
+	app.world.Update(dt)
 }
 
 //Render occurs when the screen needs updating
@@ -114,16 +132,15 @@ func (app *SpriteApp) Render() {
 
 	n.GL.Clear(n.GlColorBufferBit)
 
-	app.batch.Begin()
-
-	for _, ball := range app.balls {
-		app.batch.Draw(ball.sprite, ball.origin, ball.transform, n.White)
+	//Draw the balls
+	if err := app.world.Render(); err != nil {
+		log.Println("Failed to draw the balls", err)
 	}
 
+	//Draw the cursor over the top
+	app.batch.Begin(n.ScreenMatrix())
 	mouse := n.Input().GetMousePosition()
 	t := n.NewTransform2D(mouse, 0, Vector2{1, 1})
 	app.batch.Draw(app.cursor, Vector2{0.5, 0.5}, t, n.White)
-
-	//app.batch.Draw(app.sprite, Vector2{0, 0}, Vector2{0, 0}, Vector2{1, 1}, 0, 0xffffff, 1)
 	app.batch.End()
 }
@@ -51,7 +51,11 @@ image, err := n.LoadImage("resources/tile.png") // The image URL
 	app.texture = image.CreateTexture()
 	tileSize := float32(app.texture.Width()) / 1.0
 	app.sprite = n.NewSliceSprite(app.texture, Rectangle{tileSize * 0, 0, tileSize, float32(app.texture.Height())}, Vector2{10, 10})
-	app.batch = n.NewUIRenderer()
+	app.batch, err = n.NewUIRenderer()
+	if err != nil {
+		log.Fatalln("Failed to create the UI renderer", err)
+		return false
+	}
 
 	cursor, _ := n.LoadImage("resources/cursors.svg")
 	cursorTexture := cursor.CreateTexture()
@@ -0,0 +1,117 @@
+package noodle
+
+import (
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/lachee/noodle/internal/memfs"
+)
+
+//Files is the file system the loaders read from, such as LoadImage, LoadShaderFromURL, the Assets manager and the obj,
+// gltf and pack packages. By default it is an HTTPFS, which downloads names as URLs. Set it to an embed.FS, a MemoryFS or a
+// loaded pack to run from local data, such as in tests and offline builds.
+var Files fs.FS = HTTPFS{}
+
+//ReadFile reads a file from Files. For file systems other than HTTPFS the name is cleaned into a path the file system
+// accepts, so "/resources/tile.png" and "resources/tile.png" are the same file.
+func ReadFile(name string) ([]byte, error) {
+	if _, ok := Files.(HTTPFS); ok {
+		return fs.ReadFile(Files, name)
+	}
+	return fs.ReadFile(Files, FilePath(name))
+}
+
+//ReadString reads a file from Files as a string. It is also a ShaderLoader.
+func ReadString(name string) (string, error) {
+	data, err := ReadFile(name)
+	return string(data), err
+}
+
+//FilePath cleans a name into a path an fs.FS accepts, which has no leading slash or dot segments
+func FilePath(name string) string {
+	cleaned := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleaned == "" {
+		return "."
+	}
+	return cleaned
+}
+
+//ResolvePath gets the URL of a path relative to the file at the base URL, such as a texture referenced by a model.
+// Relative bases, such as "resources/model.obj", stay relative.
+func ResolvePath(base, name string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	nameURL, err := url.Parse(name)
+	if err != nil {
+		return "", err
+	}
+
+	if nameURL.IsAbs() || (!baseURL.IsAbs() && strings.HasPrefix(name, "/")) {
+		return name, nil
+	}
+	if !baseURL.IsAbs() && !strings.HasPrefix(base, "/") {
+		return path.Join(path.Dir(base), name), nil
+	}
+	return baseURL.ResolveReference(nameURL).String(), nil
+}
+
+//FileShaderLoader loads shader files from a file system, such as an embed.FS of the application's shaders
+func FileShaderLoader(fsys fs.FS) ShaderLoader {
+	return func(name string) (string, error) {
+		data, err := fs.ReadFile(fsys, FilePath(name))
+		return string(data), err
+	}
+}
+
+//HTTPFS downloads files with HTTP. Names are URLs, which are relative to the Base URL unless they are absolute.
+// It cannot list directories.
+type HTTPFS struct {
+	Base string
+}
+
+//url gets the URL of a name
+func (h HTTPFS) url(name string) string {
+	if h.Base == "" || strings.Contains(name, "://") {
+		return name
+	}
+	return strings.TrimSuffix(h.Base, "/") + "/" + strings.TrimPrefix(name, "/")
+}
+
+//Open downloads the file
+func (h HTTPFS) Open(name string) (fs.File, error) {
+	data, err := h.ReadFile(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return memfs.OpenFile(name, data), nil
+}
+
+//ReadFile downloads the file. Missing files return an error that matches fs.ErrNotExist.
+func (h HTTPFS) ReadFile(name string) ([]byte, error) {
+	return DownloadFile(h.url(name))
+}
+
+//MemoryFS is a file system of files held in memory, by their slash separated paths such as "shader/ui.vert". Paths have
+// no leading slash, like every fs.FS. The data is read, not copied, so do not change it while it is being read.
+type MemoryFS map[string][]byte
+
+//Open opens a file, or a directory of the files in it
+func (m MemoryFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m[name]; ok {
+		return memfs.OpenFile(name, data), nil
+	}
+
+	files := make(map[string]int64, len(m))
+	for file, data := range m {
+		files[file] = int64(len(data))
+	}
+	return memfs.OpenDir(name, files)
+}
@@ -0,0 +1,94 @@
+package noodle
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResolvePath(t *testing.T) {
+	paths := []struct{ base, name, expected string }{
+		{"resources/models/ship.obj", "ship.mtl", "resources/models/ship.mtl"},
+		{"resources/models/ship.obj", "../textures/hull.png", "resources/textures/hull.png"},
+		{"ship.obj", "ship.mtl", "ship.mtl"},
+		{"/models/ship.obj", "ship.mtl", "/models/ship.mtl"},
+		{"resources/ship.obj", "/textures/hull.png", "/textures/hull.png"},
+		{"https://cdn.example.com/models/ship.gltf", "../textures/hull.png", "https://cdn.example.com/textures/hull.png"},
+		{"https://cdn.example.com/models/ship.gltf", "/hull.png", "https://cdn.example.com/hull.png"},
+		{"resources/ship.gltf", "https://cdn.example.com/hull.png", "https://cdn.example.com/hull.png"},
+	}
+	for _, p := range paths {
+		if resolved, err := ResolvePath(p.base, p.name); err != nil || resolved != p.expected {
+			t.Errorf("%q from %q: expected %q, got %q (%v)", p.name, p.base, p.expected, resolved, err)
+		}
+	}
+}
+
+func TestFilesFromMemoryFS(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	useFiles(t, MemoryFS{
+		"resources/shader/a.vert":            []byte("#include \"lib/uniforms.glsl\"\n" + unlitVertCode),
+		"resources/shader/a.frag":            []byte(unlitFragCode),
+		"resources/shader/lib/uniforms.glsl": []byte("uniform mat4 uExtra;"),
+	})
+
+	//Names are cleaned, and includes are read relative to the file that includes them
+	shader, err := LoadShaderFromURL("/resources/shader/a.vert", "./resources/shader/a.frag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shader.Release()
+
+	if data, err := ReadString("resources/shader/../shader/lib/uniforms.glsl"); err != nil || data != "uniform mat4 uExtra;" {
+		t.Errorf("unexpected %q, %v", data, err)
+	}
+	if _, err := ReadFile("resources/missing.png"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestHTTPFSBase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/assets/tile.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("tile"))
+	}))
+	defer server.Close()
+	useFiles(t, HTTPFS{Base: server.URL + "/assets/"})
+
+	//Names are relative to the base, unless they are URLs already
+	for _, name := range []string{"tile.txt", "/tile.txt", server.URL + "/assets/tile.txt"} {
+		if data, err := ReadString(name); err != nil || data != "tile" {
+			t.Errorf("%s: unexpected %q, %v", name, data, err)
+		}
+	}
+	if info, err := fs.Stat(Files, "tile.txt"); err != nil || info.Size() != 4 {
+		t.Errorf("unexpected %v, %v", info, err)
+	}
+	if _, err := ReadFile("missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a 404 to match fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestMemoryFS(t *testing.T) {
+	files := MemoryFS{
+		"tile.png":          []byte("not really a png"),
+		"shader/ui.vert":    []byte("void main() {}"),
+		"shader/lib/a.glsl": []byte("uniform mat4 uModel;"),
+		"empty.txt":         nil,
+	}
+	if err := fstest.TestFS(files, "tile.png", "shader/ui.vert", "shader/lib/a.glsl", "empty.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files.Open("/tile.png"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("expected fs.ErrInvalid for a leading slash, got %v", err)
+	}
+	if _, err := files.Open("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
@@ -183,6 +183,13 @@ func (f *Font) GetGlyphs() map[rune]Glyph { return f.glyphs }
 //GetTexture gets the current atlas texture
 func (f *Font) GetTexture() *Texture { return f.texture }
 
+//Release deletes the texture of the font's atlas
+func (f *Font) Release() {
+	if f.texture != nil {
+		f.texture.Release()
+	}
+}
+
 //Kern gets the spacing between two runes
 func (f *Font) Kern(left, right rune) float32 { return float32(f.kerner.Kern(left, right).Round()) }
 
@@ -242,7 +249,7 @@ func (gstr *GlyphString) GetTexture() *Texture { return gstr.font.GetTexture() }
 
 //RenderSprites uses the SpriteRenderer to draw the glyphs. Its main purpose is to serve as an example on how a renderer could be writen for the fonts.
 // see noodle/font.go for this function.
-func (gstr *GlyphString) RenderSprites(renderer *SpriteRenderer, position Vector2, scale float32, color Color) {
+func (gstr *GlyphString) RenderSprites(renderer *SpriteRenderer, position Vector2, scale float32, color Color) error {
 
 	//Iterate over every position. This represents a new glyph
 	for i := range gstr.Positions {
@@ -256,6 +263,9 @@ func (gstr *GlyphString) RenderSprites(renderer *SpriteRenderer, position Vector
 		sprite := NewSprite(tex, gstr.UV[i])
 
 		//Draw it, using the bottom left as the origin
-		renderer.Draw(sprite, Vector2{0, 1}, transform, color)
+		if err := renderer.Draw(sprite, Vector2{0, 1}, transform, color); err != nil {
+			return err
+		}
 	}
+	return nil
 }
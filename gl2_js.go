@@ -0,0 +1,191 @@
+package noodle
+
+import (
+	"syscall/js"
+)
+
+//Version gets the WebGL version of the context, either 1 or 2
+func (gl *WebGL) Version() int { return gl.version }
+
+//extension gets a WebGL extension, caching the result. Returns false if the browser does not support it.
+func (gl *WebGL) extension(name string) (js.Value, bool) {
+	ext, ok := gl.extensions[name]
+	if !ok {
+		ext = gl.context.Call("getExtension", name)
+		gl.extensions[name] = ext
+	}
+	return ext, !ext.IsNull() && !ext.IsUndefined()
+}
+
+//resetExtensions forgets the cached extensions, as they are invalid once the context is restored
+func (gl *WebGL) resetExtensions() {
+	gl.extensions = make(map[string]js.Value)
+}
+
+//Supports checks if the context can use the feature, either natively or through an extension
+func (gl *WebGL) Supports(feature GLFeature) bool {
+	switch feature {
+	case FeatureVertexArrays:
+		_, ok := gl.extension("OES_vertex_array_object")
+		return gl.version >= 2 || ok
+	case FeatureInstancing:
+		_, ok := gl.extension("ANGLE_instanced_arrays")
+		return gl.version >= 2 || ok
+	case FeatureMultipleRenderTargets:
+		_, ok := gl.extension("WEBGL_draw_buffers")
+		return gl.version >= 2 || ok
+	case FeatureElementIndexUint:
+		_, ok := gl.extension("OES_element_index_uint")
+		return gl.version >= 2 || ok
+	case FeatureTexture3D, FeatureUniformBuffers:
+		return gl.version >= 2
+	default:
+		return false
+	}
+}
+
+//DrawArrays renders primitives from the bound array buffers.
+func (gl *WebGL) DrawArrays(mode GLEnum, first int, count int) {
+	gl.context.Call("drawArrays", mode, first, count)
+}
+
+//CreateFramebuffer creates a new framebuffer
+func (gl *WebGL) CreateFramebuffer() WebGLFramebuffer {
+	return gl.context.Call("createFramebuffer")
+}
+
+//BindFramebuffer binds the framebuffer to the target. A nil framebuffer binds the canvas.
+func (gl *WebGL) BindFramebuffer(target GLEnum, framebuffer WebGLFramebuffer) {
+	gl.context.Call("bindFramebuffer", target, framebuffer)
+}
+
+//FramebufferTexture2D attaches a texture to the bound framebuffer
+func (gl *WebGL) FramebufferTexture2D(target GLEnum, attachment GLEnum, textarget GLEnum, texture WebGLTexture, level int) {
+	gl.context.Call("framebufferTexture2D", target, attachment, textarget, texture, level)
+}
+
+//CreateVertexArray creates a new vertex array object
+func (gl *WebGL) CreateVertexArray() (WebGLVertexArray, error) {
+	if gl.version >= 2 {
+		return gl.context.Call("createVertexArray"), nil
+	}
+	if ext, ok := gl.extension("OES_vertex_array_object"); ok {
+		return ext.Call("createVertexArrayOES"), nil
+	}
+	return nil, ErrNotSupported
+}
+
+//BindVertexArray binds the vertex array object. A nil array binds the default.
+func (gl *WebGL) BindVertexArray(vertexArray WebGLVertexArray) {
+	if gl.version >= 2 {
+		gl.context.Call("bindVertexArray", vertexArray)
+	} else if ext, ok := gl.extension("OES_vertex_array_object"); ok {
+		ext.Call("bindVertexArrayOES", vertexArray)
+	}
+}
+
+//DeleteVertexArray deletes the vertex array object
+func (gl *WebGL) DeleteVertexArray(vertexArray WebGLVertexArray) {
+	if gl.version >= 2 {
+		gl.context.Call("deleteVertexArray", vertexArray)
+	} else if ext, ok := gl.extension("OES_vertex_array_object"); ok {
+		ext.Call("deleteVertexArrayOES", vertexArray)
+	}
+}
+
+//VertexAttribDivisor sets how many instances are drawn before the attribute advances
+func (gl *WebGL) VertexAttribDivisor(position WebGLAttributeLocation, divisor int) error {
+	if gl.version >= 2 {
+		gl.context.Call("vertexAttribDivisor", position, divisor)
+		return nil
+	}
+	if ext, ok := gl.extension("ANGLE_instanced_arrays"); ok {
+		ext.Call("vertexAttribDivisorANGLE", position, divisor)
+		return nil
+	}
+	return ErrNotSupported
+}
+
+//DrawArraysInstanced renders several instances of the primitives from the bound array buffers
+func (gl *WebGL) DrawArraysInstanced(mode GLEnum, first int, count int, instances int) error {
+	if gl.version >= 2 {
+		gl.context.Call("drawArraysInstanced", mode, first, count, instances)
+		return nil
+	}
+	if ext, ok := gl.extension("ANGLE_instanced_arrays"); ok {
+		ext.Call("drawArraysInstancedANGLE", mode, first, count, instances)
+		return nil
+	}
+	return ErrNotSupported
+}
+
+//DrawElementsInstanced renders several instances of the primitives from array data
+func (gl *WebGL) DrawElementsInstanced(mode GLEnum, count int, valueType GLEnum, offset int, instances int) error {
+	if gl.version >= 2 {
+		gl.context.Call("drawElementsInstanced", mode, count, valueType, offset, instances)
+		return nil
+	}
+	if ext, ok := gl.extension("ANGLE_instanced_arrays"); ok {
+		ext.Call("drawElementsInstancedANGLE", mode, count, valueType, offset, instances)
+		return nil
+	}
+	return ErrNotSupported
+}
+
+//TexImage3D specifies a 3D or array image. Pixels may be nil, a slice or a JS typed array.
+func (gl *WebGL) TexImage3D(target GLEnum, level int, internalFormat GLEnum, width, height, depth int, format GLEnum, texelType GLEnum, pixels interface{}) error {
+	if gl.version < 2 {
+		return ErrNotSupported
+	}
+
+	var data interface{}
+	if pixels != nil {
+		data = sliceToTypedArray(pixels)
+	}
+	gl.context.Call("texImage3D", target, level, internalFormat, width, height, depth, 0, format, texelType, data)
+	return nil
+}
+
+//GetUniformBlockIndex gets the index of a uniform block in the program
+func (gl *WebGL) GetUniformBlockIndex(shaderProgram WebGLShaderProgram, name string) (int, error) {
+	if gl.version < 2 {
+		return -1, ErrNotSupported
+	}
+	return gl.context.Call("getUniformBlockIndex", shaderProgram, name).Int(), nil
+}
+
+//UniformBlockBinding assigns a binding point to a uniform block
+func (gl *WebGL) UniformBlockBinding(shaderProgram WebGLShaderProgram, blockIndex int, binding int) error {
+	if gl.version < 2 {
+		return ErrNotSupported
+	}
+	gl.context.Call("uniformBlockBinding", shaderProgram, blockIndex, binding)
+	return nil
+}
+
+//BindBufferBase binds a buffer to an indexed binding point, such as a uniform buffer binding
+func (gl *WebGL) BindBufferBase(target GLEnum, index int, buffer WebGLBuffer) error {
+	if gl.version < 2 {
+		return ErrNotSupported
+	}
+	gl.context.Call("bindBufferBase", target, index, buffer)
+	return nil
+}
+
+//DrawBuffers sets which color attachments are drawn to
+func (gl *WebGL) DrawBuffers(buffers []GLEnum) error {
+	attachments := make([]interface{}, len(buffers))
+	for i, b := range buffers {
+		attachments[i] = b
+	}
+
+	if gl.version >= 2 {
+		gl.context.Call("drawBuffers", attachments)
+		return nil
+	}
+	if ext, ok := gl.extension("WEBGL_draw_buffers"); ok {
+		ext.Call("drawBuffersWEBGL", attachments)
+		return nil
+	}
+	return ErrNotSupported
+}
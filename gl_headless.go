@@ -0,0 +1,1059 @@
+package noodle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//GLCall is a single call that was made to a HeadlessGL
+type GLCall struct {
+	Name string        //Name is the WebGL name of the function, such as bufferData
+	Args []interface{} //Args are the arguments the function was called with
+}
+
+//DrawCall is a snapshot of the state at the time a HeadlessGL was asked to draw
+type DrawCall struct {
+	Mode               GLEnum             //Mode is the primitive that was drawn
+	First              int                //First is the starting vertex of a DrawArrays
+	Count              int                //Count is the number of elements drawn
+	ValueType          GLEnum             //ValueType is the type of the indices, or 0 for a DrawArrays
+	Offset             int                //Offset is the byte offset into the element buffer
+	Instances          int                //Instances is how many instances were drawn
+	Program            WebGLShaderProgram //Program is the program that was in use
+	Texture            WebGLTexture       //Texture is the texture bound to TEXTURE_2D of the active unit
+	ArrayBuffer        WebGLBuffer        //ArrayBuffer is the buffer bound to ARRAY_BUFFER
+	ElementArrayBuffer WebGLBuffer        //ElementArrayBuffer is the buffer bound to ELEMENT_ARRAY_BUFFER
+	VertexArray        WebGLVertexArray   //VertexArray is the bound vertex array object
+	Framebuffer        WebGLFramebuffer   //Framebuffer is the framebuffer that was drawn to, or nil for the canvas
+}
+
+type headlessBuffer struct {
+	id      int
+	data    []byte
+	usage   GLEnum
+	deleted bool
+}
+
+type headlessShader struct {
+	id         int
+	shaderType GLEnum
+	source     string
+	compiled   bool
+	infoLog    string
+}
+
+type headlessProgram struct {
+	id             int
+	shaders        []*headlessShader
+	linked         bool
+	infoLog        string
+	attribs        map[string]WebGLAttributeLocation
+	uniforms       map[string]interface{}
+	activeUniforms []WebGLActiveInfo
+	activeAttribs  []WebGLActiveInfo
+	deleted        bool
+}
+
+type headlessObject struct {
+	id   int
+	kind string
+}
+
+type headlessUniformLocation struct {
+	program *headlessProgram
+	name    string
+}
+
+type headlessTexture struct {
+	id      int
+	target  GLEnum
+	pixels  interface{}
+	params  map[GLEnum]interface{}
+	deleted bool
+}
+
+//HeadlessGL is a pure Go GLContext. It does not rasterize anything, but tracks the objects and state it is given and records every call,
+// allowing renderers to be tested without a browser.
+type HeadlessGL struct {
+	version  int
+	disabled map[GLFeature]bool
+	lost     bool
+	nextID   int
+	calls    []GLCall
+	draws    []DrawCall
+	enabled  map[GLEnum]bool
+
+	buffers       map[GLEnum]*headlessBuffer
+	program       *headlessProgram
+	activeTexture GLEnum
+	textures      map[GLEnum]map[GLEnum]*headlessTexture
+	vertexArray   *headlessObject
+	framebuffer   *headlessObject
+	drawBuffers   []GLEnum
+}
+
+//HeadlessGL must satisfy the GLContext
+var _ GLContext = (*HeadlessGL)(nil)
+
+//NewHeadlessGL creates a new GL context that does not require a browser. It behaves like a WebGL2 context.
+func NewHeadlessGL() *HeadlessGL {
+	return &HeadlessGL{
+		version:       2,
+		enabled:       make(map[GLEnum]bool),
+		buffers:       make(map[GLEnum]*headlessBuffer),
+		activeTexture: GlTexture0,
+		textures:      make(map[GLEnum]map[GLEnum]*headlessTexture),
+	}
+}
+
+//SetVersion sets the WebGL version the context pretends to be. Version 1 behaves as if the common extensions are available,
+// use SetSupported to take them away so fallbacks can be tested.
+func (gl *HeadlessGL) SetVersion(version int) { gl.version = version }
+
+//SetSupported makes a feature available or not, as if the browser did or did not have its extension.
+// Features that require WebGL2 are never available in version 1.
+func (gl *HeadlessGL) SetSupported(feature GLFeature, supported bool) {
+	if gl.disabled == nil {
+		gl.disabled = make(map[GLFeature]bool)
+	}
+	gl.disabled[feature] = !supported
+}
+
+//Version gets the WebGL version of the context
+func (gl *HeadlessGL) Version() int { return gl.version }
+
+//Supports checks if the context can use the feature
+func (gl *HeadlessGL) Supports(feature GLFeature) bool {
+	if gl.disabled[feature] {
+		return false
+	}
+
+	switch feature {
+	case FeatureVertexArrays, FeatureInstancing, FeatureMultipleRenderTargets, FeatureElementIndexUint:
+		return true
+	case FeatureTexture3D, FeatureUniformBuffers:
+		return gl.version >= 2
+	default:
+		return false
+	}
+}
+
+//IsContextLost checks if the context has been lost
+func (gl *HeadlessGL) IsContextLost() bool { return gl.lost }
+
+//LoseContext simulates the browser taking the context away. All the bound state is dropped, just like a real context.
+func (gl *HeadlessGL) LoseContext() {
+	gl.record("loseContext")
+	gl.lost = true
+	gl.enabled = make(map[GLEnum]bool)
+	gl.buffers = make(map[GLEnum]*headlessBuffer)
+	gl.program = nil
+	gl.activeTexture = GlTexture0
+	gl.textures = make(map[GLEnum]map[GLEnum]*headlessTexture)
+	gl.vertexArray = nil
+	gl.framebuffer = nil
+	gl.drawBuffers = nil
+}
+
+//RestoreContext simulates the browser giving the context back
+func (gl *HeadlessGL) RestoreContext() {
+	gl.record("restoreContext")
+	gl.lost = false
+}
+
+//DrawBufferTargets gets the color attachments last given to DrawBuffers
+func (gl *HeadlessGL) DrawBufferTargets() []GLEnum { return gl.drawBuffers }
+
+//Calls returns every call made since the context was created or last reset. The data given to bufferData and bufferSubData
+// is recorded as a copy of its bytes, as callers reuse their slices. A HeadlessPlatform resets the calls at the start of every frame.
+func (gl *HeadlessGL) Calls() []GLCall { return gl.calls }
+
+//DrawCalls returns every draw made since the context was created or last reset. A HeadlessPlatform resets the draws at the start of every frame.
+func (gl *HeadlessGL) DrawCalls() []DrawCall { return gl.draws }
+
+//ResetCalls clears the recorded calls and draws. The GL state is left untouched.
+func (gl *HeadlessGL) ResetCalls() {
+	gl.calls = nil
+	gl.draws = nil
+}
+
+//IsDeleted checks if the buffer, program or texture has been deleted
+func (gl *HeadlessGL) IsDeleted(object interface{}) bool {
+	switch o := object.(type) {
+	case *headlessBuffer:
+		return o.deleted
+	case *headlessProgram:
+		return o.deleted
+	case *headlessTexture:
+		return o.deleted
+	default:
+		return false
+	}
+}
+
+//IsEnabled checks if the option has been enabled
+func (gl *HeadlessGL) IsEnabled(option GLEnum) bool { return gl.enabled[option] }
+
+//BufferContents gets a copy of the data currently stored in the buffer
+func (gl *HeadlessGL) BufferContents(buffer WebGLBuffer) []byte {
+	b, ok := buffer.(*headlessBuffer)
+	if !ok || b == nil {
+		return nil
+	}
+	return append([]byte(nil), b.data...)
+}
+
+//UniformValue gets the last value that was uploaded to the uniform of the program
+func (gl *HeadlessGL) UniformValue(shaderProgram WebGLShaderProgram, name string) interface{} {
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok || p == nil {
+		return nil
+	}
+	return p.uniforms[name]
+}
+
+//TexturePixels gets the pixels that were last given to the texture
+func (gl *HeadlessGL) TexturePixels(texture WebGLTexture) interface{} {
+	t, ok := texture.(*headlessTexture)
+	if !ok || t == nil {
+		return nil
+	}
+	return t.pixels
+}
+
+//record appends a call to the log
+func (gl *HeadlessGL) record(name string, args ...interface{}) {
+	gl.calls = append(gl.calls, GLCall{name, args})
+}
+
+//id generates the next object id
+func (gl *HeadlessGL) id() int {
+	gl.nextID++
+	return gl.nextID
+}
+
+//boundTexture gets the texture bound to the target of the active texture unit
+func (gl *HeadlessGL) boundTexture(target GLEnum) *headlessTexture {
+	unit := gl.textures[gl.activeTexture]
+	if unit == nil {
+		return nil
+	}
+	return unit[target]
+}
+
+//NewBuffer creates, binds and sets the data of a new buffer
+func (gl *HeadlessGL) NewBuffer(target GLEnum, data interface{}, usage GLEnum) WebGLBuffer {
+	buffer := gl.CreateBuffer()
+	gl.BindBuffer(target, buffer)
+	gl.BufferData(target, data, usage)
+	return buffer
+}
+
+//CreateBuffer creates a WebGLBuffer object.
+func (gl *HeadlessGL) CreateBuffer() WebGLBuffer {
+	buffer := &headlessBuffer{id: gl.id()}
+	gl.record("createBuffer")
+	return buffer
+}
+
+//BindBuffer binds a given WebGLBuffer to a target.
+func (gl *HeadlessGL) BindBuffer(target GLEnum, buffer WebGLBuffer) {
+	gl.record("bindBuffer", target, buffer)
+	b, _ := buffer.(*headlessBuffer)
+	gl.buffers[target] = b
+}
+
+//BufferData sets the data of a buffer
+func (gl *HeadlessGL) BufferData(target GLEnum, data interface{}, usage GLEnum) {
+	bytes := append([]byte(nil), sliceToByteSlice(data)...)
+	gl.record("bufferData", target, bytes, usage)
+	if b := gl.buffers[target]; b != nil {
+		b.data = append([]byte(nil), bytes...)
+		b.usage = usage
+	}
+}
+
+//BufferSubData updates a subset of a buffer object's data store.
+func (gl *HeadlessGL) BufferSubData(target GLEnum, offset int, data interface{}) {
+	bytes := append([]byte(nil), sliceToByteSlice(data)...)
+	gl.record("bufferSubData", target, offset, bytes)
+	if b := gl.buffers[target]; b != nil {
+		if offset+len(bytes) > len(b.data) {
+			b.data = append(b.data, make([]byte, offset+len(bytes)-len(b.data))...)
+		}
+		copy(b.data[offset:], bytes)
+	}
+}
+
+//DeleteBuffer deletes the buffer, unbinding it from any target
+func (gl *HeadlessGL) DeleteBuffer(buffer WebGLBuffer) {
+	gl.record("deleteBuffer", buffer)
+	b, ok := buffer.(*headlessBuffer)
+	if !ok || b == nil {
+		return
+	}
+
+	b.deleted = true
+	for target, bound := range gl.buffers {
+		if bound == b {
+			delete(gl.buffers, target)
+		}
+	}
+}
+
+//CreateShader creates a new WebGLShader
+func (gl *HeadlessGL) CreateShader(shaderType GLEnum) WebGLShader {
+	gl.record("createShader", shaderType)
+	return &headlessShader{id: gl.id(), shaderType: shaderType}
+}
+
+//ShaderSource sets the shader source code
+func (gl *HeadlessGL) ShaderSource(shader WebGLShader, source string) {
+	gl.record("shaderSource", shader, source)
+	if s, ok := shader.(*headlessShader); ok {
+		s.source = source
+	}
+}
+
+//CompileShader compiles the shader. As there is no GLSL compiler, the only source that fails is an empty one.
+func (gl *HeadlessGL) CompileShader(shader WebGLShader) error {
+	gl.record("compileShader", shader)
+	s, ok := shader.(*headlessShader)
+	if !ok {
+		return errors.New("invalid shader")
+	}
+
+	s.compiled = strings.TrimSpace(s.source) != ""
+	if !s.compiled {
+		s.infoLog = "ERROR: 0:0: empty shader source"
+		return errors.New(s.infoLog)
+	}
+
+	s.infoLog = ""
+	return nil
+}
+
+//GetShaderParameter returns information about the given shader.
+func (gl *HeadlessGL) GetShaderParameter(shader WebGLShader, param GLEnum) interface{} {
+	gl.record("getShaderParameter", shader, param)
+	s, ok := shader.(*headlessShader)
+	if !ok {
+		return nil
+	}
+
+	switch param {
+	case GlCompileStatus:
+		return s.compiled
+	case GlShaderType:
+		return s.shaderType
+	default:
+		return nil
+	}
+}
+
+//GetShaderInfoLog returns the information log for the specified WebGLShader object.
+func (gl *HeadlessGL) GetShaderInfoLog(shader WebGLShader) string {
+	gl.record("getShaderInfoLog", shader)
+	if s, ok := shader.(*headlessShader); ok {
+		return s.infoLog
+	}
+	return ""
+}
+
+//NewShader creates, sources and compiles a new shader
+func (gl *HeadlessGL) NewShader(shaderType GLEnum, sourceCode string) (WebGLShader, error) {
+	shader := gl.CreateShader(shaderType)
+	gl.ShaderSource(shader, sourceCode)
+	err := gl.CompileShader(shader)
+	return shader, err
+}
+
+//DeleteShader marks a given WebGLShader object for deletion.
+func (gl *HeadlessGL) DeleteShader(shader WebGLShader) {
+	gl.record("deleteShader", shader)
+}
+
+//CreateProgram creates a new webgl shader program
+func (gl *HeadlessGL) CreateProgram() WebGLShaderProgram {
+	gl.record("createProgram")
+	return &headlessProgram{
+		id:       gl.id(),
+		attribs:  make(map[string]WebGLAttributeLocation),
+		uniforms: make(map[string]interface{}),
+	}
+}
+
+//AttachShader attaches a shader to the program
+func (gl *HeadlessGL) AttachShader(shaderProgram WebGLShaderProgram, shader WebGLShader) {
+	gl.record("attachShader", shaderProgram, shader)
+	p, ok := shaderProgram.(*headlessProgram)
+	s, ok2 := shader.(*headlessShader)
+	if ok && ok2 {
+		p.shaders = append(p.shaders, s)
+	}
+}
+
+//LinkProgram links a given WebGLProgram. It fails if a shader is missing or did not compile.
+func (gl *HeadlessGL) LinkProgram(shaderProgram WebGLShaderProgram) error {
+	gl.record("linkProgram", shaderProgram)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return errors.New("invalid program")
+	}
+
+	p.linked = false
+	p.infoLog = ""
+	hasVertex, hasFragment := false, false
+	for _, s := range p.shaders {
+		if !s.compiled {
+			p.infoLog = fmt.Sprintf("shader %d did not compile", s.id)
+			return errors.New(p.infoLog)
+		}
+		hasVertex = hasVertex || s.shaderType == GlVertexShader
+		hasFragment = hasFragment || s.shaderType == GlFragmentShader
+	}
+
+	if !hasVertex || !hasFragment {
+		p.infoLog = "missing vertex or fragment shader"
+		return errors.New(p.infoLog)
+	}
+
+	p.linked = true
+	p.activeUniforms = reflectDeclarations(p.shaders, "uniform")
+
+	//Attributes are the inputs of the vertex shader, which GLSL 3 declares with in
+	var vertexShaders []*headlessShader
+	for _, s := range p.shaders {
+		if s.shaderType == GlVertexShader {
+			vertexShaders = append(vertexShaders, s)
+		}
+	}
+	p.activeAttribs = append(reflectDeclarations(vertexShaders, "attribute"), reflectDeclarations(vertexShaders, "in")...)
+	return nil
+}
+
+//glslTypes are the GL types of the GLSL type names
+var glslTypes = map[string]GLEnum{
+	"float": GlFloat, "vec2": GlFloatVec2, "vec3": GlFloatVec3, "vec4": GlFloatVec4,
+	"int": GlInt, "ivec2": GlIntVec2, "ivec3": GlIntVec3, "ivec4": GlIntVec4,
+	"bool": GlBool, "bvec2": GlBoolVec2, "bvec3": GlBoolVec3, "bvec4": GlBoolVec4,
+	"mat2": GlFloatMat2, "mat3": GlFloatMat3, "mat4": GlFloatMat4,
+	"sampler2D": GlSampler2d, "samplerCube": GlSamplerCube, "sampler3D": GlSampler3d, "sampler2DArray": GlSampler2dArray,
+}
+
+//reflectDeclarations finds the variables the shaders declare with the qualifier, such as uniform, in the order they first appear.
+// There is no compiler to remove unused variables, so everything that is declared is treated as active.
+func reflectDeclarations(shaders []*headlessShader, qualifier string) []WebGLActiveInfo {
+	var infos []WebGLActiveInfo
+	seen := make(map[string]bool)
+	for _, shader := range shaders {
+		for _, statement := range strings.Split(stripComments(shader.source), ";") {
+			fields := strings.Fields(strings.Replace(statement, ",", " , ", -1))
+			for i, field := range fields {
+				if field != qualifier {
+					continue
+				}
+
+				//Skip the precision to find the type, then everything after is a list of names
+				rest := fields[i+1:]
+				if len(rest) > 0 && (rest[0] == "lowp" || rest[0] == "mediump" || rest[0] == "highp") {
+					rest = rest[1:]
+				}
+				if len(rest) < 2 {
+					break
+				}
+				valueType, ok := glslTypes[rest[0]]
+				if !ok {
+					break
+				}
+
+				for _, name := range strings.Split(strings.Join(rest[1:], ""), ",") {
+					info := WebGLActiveInfo{Name: name, Size: 1, Type: valueType}
+					if open := strings.IndexByte(name, '['); open >= 0 && strings.HasSuffix(name, "]") {
+						fmt.Sscanf(name[open+1:len(name)-1], "%d", &info.Size)
+						info.Name = name[:open] + "[0]"
+					}
+					if isIdentifier(strings.TrimSuffix(info.Name, "[0]")) && !seen[info.Name] {
+						seen[info.Name] = true
+						infos = append(infos, info)
+					}
+				}
+				break
+			}
+		}
+	}
+	return infos
+}
+
+//isIdentifier checks if the name is a valid GLSL identifier
+func isIdentifier(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+//stripComments removes the // and /* */ comments from GLSL source
+func stripComments(source string) string {
+	var result strings.Builder
+	for i := 0; i < len(source); i++ {
+		switch {
+		case strings.HasPrefix(source[i:], "//"):
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+			result.WriteByte('\n')
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return result.String()
+			}
+			i += end + 3
+			result.WriteByte(' ')
+		default:
+			result.WriteByte(source[i])
+		}
+	}
+	return result.String()
+}
+
+//UseProgram tells webgl to start using this program
+func (gl *HeadlessGL) UseProgram(shaderProgram WebGLShaderProgram) {
+	gl.record("useProgram", shaderProgram)
+	p, _ := shaderProgram.(*headlessProgram)
+	gl.program = p
+}
+
+//GetProgramParameter returns information about the given program.
+func (gl *HeadlessGL) GetProgramParameter(shaderProgram WebGLShaderProgram, param GLEnum) interface{} {
+	gl.record("getProgramParameter", shaderProgram, param)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return nil
+	}
+
+	switch param {
+	case GlLinkStatus:
+		return p.linked
+	case GlAttachedShaders:
+		return len(p.shaders)
+	case GlActiveUniforms:
+		return len(p.activeUniforms)
+	case GlActiveAttributes:
+		return len(p.activeAttribs)
+	default:
+		return nil
+	}
+}
+
+//GetProgramInfoLog returns the information log for the specified WebGLProgram object.
+func (gl *HeadlessGL) GetProgramInfoLog(shaderProgram WebGLShaderProgram) string {
+	gl.record("getProgramInfoLog", shaderProgram)
+	if p, ok := shaderProgram.(*headlessProgram); ok {
+		return p.infoLog
+	}
+	return ""
+}
+
+//NewProgram creates a new webgl shader program with some shaders and links it
+func (gl *HeadlessGL) NewProgram(shaders []WebGLShader) (WebGLShaderProgram, error) {
+	program := gl.CreateProgram()
+	for _, shader := range shaders {
+		gl.AttachShader(program, shader)
+	}
+
+	err := gl.LinkProgram(program)
+	return program, err
+}
+
+//GetUniformLocation returns the location of a specific uniform variable which is part of a given WebGLProgram.
+func (gl *HeadlessGL) GetUniformLocation(shaderProgram WebGLShaderProgram, location string) WebGLUniformLocation {
+	gl.record("getUniformLocation", shaderProgram, location)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return nil
+	}
+	return &headlessUniformLocation{p, location}
+}
+
+//GetAttribLocation gets a location of an attribute. Locations are handed out in the order they are first asked for.
+func (gl *HeadlessGL) GetAttribLocation(shaderProgram WebGLShaderProgram, attribute string) WebGLAttributeLocation {
+	gl.record("getAttribLocation", shaderProgram, attribute)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return -1
+	}
+
+	location, exists := p.attribs[attribute]
+	if !exists {
+		location = len(p.attribs)
+		p.attribs[attribute] = location
+	}
+	return location
+}
+
+//GetActiveUniform describes one of the uniforms the program's shaders declare
+func (gl *HeadlessGL) GetActiveUniform(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error) {
+	gl.record("getActiveUniform", shaderProgram, index)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return WebGLActiveInfo{}, errors.New("invalid program")
+	}
+	if index < 0 || index >= len(p.activeUniforms) {
+		return WebGLActiveInfo{}, fmt.Errorf("uniform %d is not active", index)
+	}
+	return p.activeUniforms[index], nil
+}
+
+//GetActiveAttrib describes one of the attributes the program's vertex shader declares
+func (gl *HeadlessGL) GetActiveAttrib(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error) {
+	gl.record("getActiveAttrib", shaderProgram, index)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return WebGLActiveInfo{}, errors.New("invalid program")
+	}
+	if index < 0 || index >= len(p.activeAttribs) {
+		return WebGLActiveInfo{}, fmt.Errorf("attribute %d is not active", index)
+	}
+	return p.activeAttribs[index], nil
+}
+
+//DeleteProgram deletes the program, unbinding it if it is in use
+func (gl *HeadlessGL) DeleteProgram(shaderProgram WebGLShaderProgram) {
+	gl.record("deleteProgram", shaderProgram)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok || p == nil {
+		return
+	}
+
+	p.deleted = true
+	if gl.program == p {
+		gl.program = nil
+	}
+}
+
+//VertexAttribPointer binds the buffer currently bound to gl.ARRAY_BUFFER to a generic vertex attribute and specifies its layout.
+func (gl *HeadlessGL) VertexAttribPointer(position WebGLAttributeLocation, size int, valueType GLEnum, normalized bool, stride int, offset int) {
+	gl.record("vertexAttribPointer", position, size, valueType, normalized, stride, offset)
+}
+
+//EnableVertexAttribArray turns on the generic vertex attribute array at the specified index.
+func (gl *HeadlessGL) EnableVertexAttribArray(position WebGLAttributeLocation) {
+	gl.record("enableVertexAttribArray", position)
+}
+
+//DisableVertexAttribArray turns off the generic vertex attribute array at the specified index.
+func (gl *HeadlessGL) DisableVertexAttribArray(position WebGLAttributeLocation) {
+	gl.record("disableVertexAttribArray", position)
+}
+
+//VertexAttrib4f sets the value a generic vertex attribute has when its array is turned off
+func (gl *HeadlessGL) VertexAttrib4f(position WebGLAttributeLocation, x, y, z, w float32) {
+	gl.record("vertexAttrib4f", position, x, y, z, w)
+}
+
+//ClearColor sets the colour the screen will be cleared to
+func (gl *HeadlessGL) ClearColor(r, g, b, a float64) {
+	gl.record("clearColor", r, g, b, a)
+}
+
+//ClearDepth sets the z value that is set to the depth buffer every frame
+func (gl *HeadlessGL) ClearDepth(depth float64) {
+	gl.record("clearDepth", depth)
+}
+
+//Viewport sets the viewport
+func (gl *HeadlessGL) Viewport(x, y, width, height int) {
+	gl.record("viewport", x, y, width, height)
+}
+
+//DepthFunc specifies a function that compares incoming pixel depth to the current depth buffer value.
+func (gl *HeadlessGL) DepthFunc(function GLEnum) {
+	gl.record("depthFunc", function)
+}
+
+//BlendFunc specifies the pixel arithmetic used for blending
+func (gl *HeadlessGL) BlendFunc(sFactor GLEnum, gFactor GLEnum) {
+	gl.record("blendFunc", sFactor, gFactor)
+}
+
+//Enable enables a option
+func (gl *HeadlessGL) Enable(option GLEnum) {
+	gl.record("enable", option)
+	gl.enabled[option] = true
+}
+
+//Disable disables a option
+func (gl *HeadlessGL) Disable(option GLEnum) {
+	gl.record("disable", option)
+	gl.enabled[option] = false
+}
+
+//Clear empties the buffers
+func (gl *HeadlessGL) Clear(option GLEnum) {
+	gl.record("clear", option)
+}
+
+//DrawElements records the draw along with the state it was made in.
+func (gl *HeadlessGL) DrawElements(mode GLEnum, count int, valueType GLEnum, offset int) {
+	gl.record("drawElements", mode, count, valueType, offset)
+	gl.draw(DrawCall{Mode: mode, Count: count, ValueType: valueType, Offset: offset, Instances: 1})
+}
+
+//DrawArrays records the draw along with the state it was made in.
+func (gl *HeadlessGL) DrawArrays(mode GLEnum, first int, count int) {
+	gl.record("drawArrays", mode, first, count)
+	gl.draw(DrawCall{Mode: mode, First: first, Count: count, Instances: 1})
+}
+
+//draw fills in the bound state and stores the draw call
+func (gl *HeadlessGL) draw(draw DrawCall) {
+	if gl.program != nil {
+		draw.Program = gl.program
+	}
+	if t := gl.boundTexture(GlTexture2D); t != nil {
+		draw.Texture = t
+	}
+	if b := gl.buffers[GlArrayBuffer]; b != nil {
+		draw.ArrayBuffer = b
+	}
+	if b := gl.buffers[GlElementArrayBuffer]; b != nil {
+		draw.ElementArrayBuffer = b
+	}
+	if gl.vertexArray != nil {
+		draw.VertexArray = gl.vertexArray
+	}
+	if gl.framebuffer != nil {
+		draw.Framebuffer = gl.framebuffer
+	}
+	gl.draws = append(gl.draws, draw)
+}
+
+//CreateTexture creates a new texture
+func (gl *HeadlessGL) CreateTexture() WebGLTexture {
+	gl.record("createTexture")
+	return &headlessTexture{id: gl.id(), params: make(map[GLEnum]interface{})}
+}
+
+//BindTexture binds a given WebGLTexture to a target (binding point).
+func (gl *HeadlessGL) BindTexture(target GLEnum, texture WebGLTexture) {
+	gl.record("bindTexture", target, texture)
+	unit := gl.textures[gl.activeTexture]
+	if unit == nil {
+		unit = make(map[GLEnum]*headlessTexture)
+		gl.textures[gl.activeTexture] = unit
+	}
+
+	t, _ := texture.(*headlessTexture)
+	if t != nil {
+		t.target = target
+	}
+	unit[target] = t
+}
+
+//UnbindTexture unbinds the target texture.
+func (gl *HeadlessGL) UnbindTexture(target GLEnum) {
+	gl.BindTexture(target, nil)
+}
+
+//ActiveTexture tells WebGL what texture state will be now modified
+func (gl *HeadlessGL) ActiveTexture(target GLEnum) {
+	gl.record("activeTexture", target)
+	gl.activeTexture = target
+}
+
+//TexImage2D stores the pixels against the bound texture
+func (gl *HeadlessGL) TexImage2D(target GLEnum, level int, internalFormat GLEnum, format GLEnum, texelType GLEnum, pixels interface{}) {
+	gl.record("texImage2D", target, level, internalFormat, format, texelType, pixels)
+	if t := gl.boundTexture(target); t != nil && level == 0 {
+		t.pixels = pixels
+	}
+}
+
+//GenerateMipmap creats the Mipmap for a texture
+func (gl *HeadlessGL) GenerateMipmap(target GLEnum) {
+	gl.record("generateMipmap", target)
+}
+
+//TexParameteri set texture parameters
+func (gl *HeadlessGL) TexParameteri(target GLEnum, param GLEnum, value int) {
+	gl.record("texParameteri", target, param, value)
+	if t := gl.boundTexture(target); t != nil {
+		t.params[param] = value
+	}
+}
+
+//TexParameterf set texture parameters
+func (gl *HeadlessGL) TexParameterf(target GLEnum, param GLEnum, value float64) {
+	gl.record("texParameterf", target, param, value)
+	if t := gl.boundTexture(target); t != nil {
+		t.params[param] = value
+	}
+}
+
+//DeleteTexture deletes the texture, unbinding it from every unit
+func (gl *HeadlessGL) DeleteTexture(texture WebGLTexture) {
+	gl.record("deleteTexture", texture)
+	t, ok := texture.(*headlessTexture)
+	if !ok || t == nil {
+		return
+	}
+
+	t.deleted = true
+	for _, unit := range gl.textures {
+		for target, bound := range unit {
+			if bound == t {
+				delete(unit, target)
+			}
+		}
+	}
+}
+
+//=== Uniform Setting
+
+//setUniform stores the value of the uniform against its program
+func (gl *HeadlessGL) setUniform(location WebGLUniformLocation, value interface{}) {
+	if l, ok := location.(*headlessUniformLocation); ok && l != nil {
+		l.program.uniforms[l.name] = value
+	}
+}
+
+//Uniform1f specifies values of uniform variables
+func (gl *HeadlessGL) Uniform1f(location WebGLUniformLocation, value float32) {
+	gl.record("uniform1f", location, value)
+	gl.setUniform(location, value)
+}
+
+//Uniform1fv specifies values of uniform variables
+func (gl *HeadlessGL) Uniform1fv(location WebGLUniformLocation, value []float32) {
+	gl.record("uniform1fv", location, value)
+	gl.setUniform(location, append([]float32(nil), value...))
+}
+
+//Uniform1i specifies values of uniform variables
+func (gl *HeadlessGL) Uniform1i(location WebGLUniformLocation, value int) {
+	gl.record("uniform1i", location, value)
+	gl.setUniform(location, value)
+}
+
+//Uniform1iv specifies values of uniform variables
+func (gl *HeadlessGL) Uniform1iv(location WebGLUniformLocation, value []int) {
+	gl.record("uniform1iv", location, value)
+	gl.setUniform(location, append([]int(nil), value...))
+}
+
+//Uniform2f specifies values of uniform variables
+func (gl *HeadlessGL) Uniform2f(location WebGLUniformLocation, value, value2 float32) {
+	gl.record("uniform2f", location, value, value2)
+	gl.setUniform(location, Vector2{value, value2})
+}
+
+//Uniform2fv specifies values of uniform variables
+func (gl *HeadlessGL) Uniform2fv(location WebGLUniformLocation, value []float32) {
+	gl.record("uniform2fv", location, value)
+	gl.setUniform(location, append([]float32(nil), value...))
+}
+
+//Uniform2i specifies values of uniform variables
+func (gl *HeadlessGL) Uniform2i(location WebGLUniformLocation, value, value2 int) {
+	gl.record("uniform2i", location, value, value2)
+	gl.setUniform(location, [2]int{value, value2})
+}
+
+//Uniform2iv specifies values of uniform variables
+func (gl *HeadlessGL) Uniform2iv(location WebGLUniformLocation, value []int) {
+	gl.record("uniform2iv", location, value)
+	gl.setUniform(location, append([]int(nil), value...))
+}
+
+//Uniform2v is an alias of Uniform2fv but with Vector support
+func (gl *HeadlessGL) Uniform2v(location WebGLUniformLocation, value Vector2) {
+	gl.record("uniform2fv", location, value)
+	gl.setUniform(location, value)
+}
+
+//Uniform3f specifies values of uniform variables
+func (gl *HeadlessGL) Uniform3f(location WebGLUniformLocation, value, value2, value3 float32) {
+	gl.record("uniform3f", location, value, value2, value3)
+	gl.setUniform(location, Vector3{value, value2, value3})
+}
+
+//Uniform3v is an alias of Uniform3fv but with Vector support
+func (gl *HeadlessGL) Uniform3v(location WebGLUniformLocation, value Vector3) {
+	gl.record("uniform3fv", location, value)
+	gl.setUniform(location, value)
+}
+
+//Uniform4f specifies values of uniform variables
+func (gl *HeadlessGL) Uniform4f(location WebGLUniformLocation, value, value2, value3, value4 float32) {
+	gl.record("uniform4f", location, value, value2, value3, value4)
+	gl.setUniform(location, Vector4{value, value2, value3, value4})
+}
+
+//Uniform4v is an alias of Uniform4fv but with Vector support
+func (gl *HeadlessGL) Uniform4v(location WebGLUniformLocation, value Vector4) {
+	gl.record("uniform4fv", location, value)
+	gl.setUniform(location, value)
+}
+
+//UniformMatrix4fv specify matrix values for uniform variables.
+func (gl *HeadlessGL) UniformMatrix4fv(location WebGLUniformLocation, matrix Matrix) {
+	gl.record("uniformMatrix4fv", location, matrix)
+	gl.setUniform(location, matrix)
+}
+
+//=== Framebuffers
+
+//CreateFramebuffer creates a new framebuffer
+func (gl *HeadlessGL) CreateFramebuffer() WebGLFramebuffer {
+	gl.record("createFramebuffer")
+	return &headlessObject{gl.id(), "framebuffer"}
+}
+
+//BindFramebuffer binds the framebuffer to the target. A nil framebuffer binds the canvas.
+func (gl *HeadlessGL) BindFramebuffer(target GLEnum, framebuffer WebGLFramebuffer) {
+	gl.record("bindFramebuffer", target, framebuffer)
+	f, _ := framebuffer.(*headlessObject)
+	gl.framebuffer = f
+}
+
+//FramebufferTexture2D attaches a texture to the bound framebuffer
+func (gl *HeadlessGL) FramebufferTexture2D(target GLEnum, attachment GLEnum, textarget GLEnum, texture WebGLTexture, level int) {
+	gl.record("framebufferTexture2D", target, attachment, textarget, texture, level)
+}
+
+//=== WebGL2
+
+//CreateVertexArray creates a new vertex array object
+func (gl *HeadlessGL) CreateVertexArray() (WebGLVertexArray, error) {
+	if !gl.Supports(FeatureVertexArrays) {
+		return nil, ErrNotSupported
+	}
+	gl.record("createVertexArray")
+	return &headlessObject{gl.id(), "vertexArray"}, nil
+}
+
+//BindVertexArray binds the vertex array object. A nil array binds the default.
+func (gl *HeadlessGL) BindVertexArray(vertexArray WebGLVertexArray) {
+	if !gl.Supports(FeatureVertexArrays) {
+		return
+	}
+	gl.record("bindVertexArray", vertexArray)
+	v, _ := vertexArray.(*headlessObject)
+	gl.vertexArray = v
+}
+
+//DeleteVertexArray deletes the vertex array object
+func (gl *HeadlessGL) DeleteVertexArray(vertexArray WebGLVertexArray) {
+	if !gl.Supports(FeatureVertexArrays) {
+		return
+	}
+	gl.record("deleteVertexArray", vertexArray)
+	if v, _ := vertexArray.(*headlessObject); v != nil && v == gl.vertexArray {
+		gl.vertexArray = nil
+	}
+}
+
+//VertexAttribDivisor sets how many instances are drawn before the attribute advances
+func (gl *HeadlessGL) VertexAttribDivisor(position WebGLAttributeLocation, divisor int) error {
+	if !gl.Supports(FeatureInstancing) {
+		return ErrNotSupported
+	}
+	gl.record("vertexAttribDivisor", position, divisor)
+	return nil
+}
+
+//DrawArraysInstanced records the draw along with the state it was made in.
+func (gl *HeadlessGL) DrawArraysInstanced(mode GLEnum, first int, count int, instances int) error {
+	if !gl.Supports(FeatureInstancing) {
+		return ErrNotSupported
+	}
+	gl.record("drawArraysInstanced", mode, first, count, instances)
+	gl.draw(DrawCall{Mode: mode, First: first, Count: count, Instances: instances})
+	return nil
+}
+
+//DrawElementsInstanced records the draw along with the state it was made in.
+func (gl *HeadlessGL) DrawElementsInstanced(mode GLEnum, count int, valueType GLEnum, offset int, instances int) error {
+	if !gl.Supports(FeatureInstancing) {
+		return ErrNotSupported
+	}
+	gl.record("drawElementsInstanced", mode, count, valueType, offset, instances)
+	gl.draw(DrawCall{Mode: mode, Count: count, ValueType: valueType, Offset: offset, Instances: instances})
+	return nil
+}
+
+//TexImage3D stores the pixels against the bound texture
+func (gl *HeadlessGL) TexImage3D(target GLEnum, level int, internalFormat GLEnum, width, height, depth int, format GLEnum, texelType GLEnum, pixels interface{}) error {
+	if !gl.Supports(FeatureTexture3D) {
+		return ErrNotSupported
+	}
+
+	gl.record("texImage3D", target, level, internalFormat, width, height, depth, format, texelType, pixels)
+	if t := gl.boundTexture(target); t != nil && level == 0 {
+		t.pixels = pixels
+	}
+	return nil
+}
+
+//GetUniformBlockIndex gets the index of a uniform block in the program. Indices are handed out in the order they are first asked for.
+func (gl *HeadlessGL) GetUniformBlockIndex(shaderProgram WebGLShaderProgram, name string) (int, error) {
+	if !gl.Supports(FeatureUniformBuffers) {
+		return -1, ErrNotSupported
+	}
+
+	gl.record("getUniformBlockIndex", shaderProgram, name)
+	p, ok := shaderProgram.(*headlessProgram)
+	if !ok {
+		return -1, nil
+	}
+
+	key := "block:" + name
+	index, exists := p.uniforms[key].(int)
+	if !exists {
+		index = 0
+		for k := range p.uniforms {
+			if strings.HasPrefix(k, "block:") {
+				index++
+			}
+		}
+		p.uniforms[key] = index
+	}
+	return index, nil
+}
+
+//UniformBlockBinding assigns a binding point to a uniform block
+func (gl *HeadlessGL) UniformBlockBinding(shaderProgram WebGLShaderProgram, blockIndex int, binding int) error {
+	if !gl.Supports(FeatureUniformBuffers) {
+		return ErrNotSupported
+	}
+	gl.record("uniformBlockBinding", shaderProgram, blockIndex, binding)
+	return nil
+}
+
+//BindBufferBase binds a buffer to an indexed binding point, such as a uniform buffer binding
+func (gl *HeadlessGL) BindBufferBase(target GLEnum, index int, buffer WebGLBuffer) error {
+	if !gl.Supports(FeatureUniformBuffers) {
+		return ErrNotSupported
+	}
+	gl.record("bindBufferBase", target, index, buffer)
+	b, _ := buffer.(*headlessBuffer)
+	gl.buffers[target] = b
+	return nil
+}
+
+//DrawBuffers sets which color attachments are drawn to
+func (gl *HeadlessGL) DrawBuffers(buffers []GLEnum) error {
+	if !gl.Supports(FeatureMultipleRenderTargets) {
+		return ErrNotSupported
+	}
+	gl.record("drawBuffers", buffers)
+	gl.drawBuffers = append([]GLEnum(nil), buffers...)
+	return nil
+}
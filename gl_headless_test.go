@@ -0,0 +1,191 @@
+package noodle
+
+import (
+	"bytes"
+	"testing"
+)
+
+//headlessApp is an Application that calls render every frame
+type headlessApp struct {
+	render func()
+}
+
+func (app *headlessApp) Start() bool    { return true }
+func (app *headlessApp) Update(float32) {}
+func (app *headlessApp) Render() {
+	if app.render != nil {
+		app.render()
+	}
+}
+
+//startHeadless starts the application on a new headless platform, exiting it once the test is done
+func startHeadless(t *testing.T, app Application) *HeadlessPlatform {
+	t.Helper()
+	platform := NewHeadlessPlatform(800, 600)
+	ctx, err := StartPlatform(app, platform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ctx.Exit)
+	return platform
+}
+
+//callsNamed gets the recorded calls with the name
+func callsNamed(gl *HeadlessGL, name string) []GLCall {
+	var calls []GLCall
+	for _, call := range gl.Calls() {
+		if call.Name == name {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestHeadlessGLRecordsBufferCopies(t *testing.T) {
+	gl := NewHeadlessGL()
+	data := []uint8{1, 2, 3, 4}
+	buffer := gl.NewBuffer(GlArrayBuffer, data, GlDynamicDraw)
+
+	//Callers reuse their slices, which must not change what was recorded
+	data[0] = 9
+	gl.BufferSubData(GlArrayBuffer, 2, data[:2])
+	data[1] = 8
+
+	uploads := callsNamed(gl, "bufferData")
+	if len(uploads) != 1 || !bytes.Equal(uploads[0].Args[1].([]byte), []byte{1, 2, 3, 4}) {
+		t.Fatalf("bufferData recorded %v", uploads)
+	}
+	updates := callsNamed(gl, "bufferSubData")
+	if len(updates) != 1 || !bytes.Equal(updates[0].Args[2].([]byte), []byte{9, 2}) {
+		t.Fatalf("bufferSubData recorded %v", updates)
+	}
+	if contents := gl.BufferContents(buffer); !bytes.Equal(contents, []byte{1, 2, 9, 2}) {
+		t.Fatalf("buffer contains %v", contents)
+	}
+}
+
+func TestHeadlessGLDrawCallState(t *testing.T) {
+	gl := NewHeadlessGL()
+	vertex, _ := gl.NewShader(GlVertexShader, "void main() {}")
+	fragment, _ := gl.NewShader(GlFragmentShader, "void main() {}")
+	program, err := gl.NewProgram([]WebGLShader{vertex, fragment})
+	if err != nil {
+		t.Fatal(err)
+	}
+	texture := gl.CreateTexture()
+	elements := gl.NewBuffer(GlElementArrayBuffer, []uint16{0, 1, 2}, GlStaticDraw)
+
+	gl.UseProgram(program)
+	gl.BindTexture(GlTexture2D, texture)
+	gl.DrawElements(GlTriangles, 3, GlUnsignedShort, 0)
+	gl.UnbindTexture(GlTexture2D)
+	gl.DrawArrays(GlLines, 2, 4)
+
+	draws := gl.DrawCalls()
+	if len(draws) != 2 {
+		t.Fatalf("expected 2 draws, got %d", len(draws))
+	}
+	if draws[0].Mode != GlTriangles || draws[0].Count != 3 || draws[0].ValueType != GlUnsignedShort ||
+		draws[0].Program != program || draws[0].Texture != texture || draws[0].ElementArrayBuffer != elements {
+		t.Errorf("unexpected element draw %+v", draws[0])
+	}
+	if draws[1].Mode != GlLines || draws[1].First != 2 || draws[1].Count != 4 || draws[1].Texture != nil {
+		t.Errorf("unexpected array draw %+v", draws[1])
+	}
+
+	gl.ResetCalls()
+	if len(gl.Calls()) != 0 || len(gl.DrawCalls()) != 0 {
+		t.Error("ResetCalls kept the calls")
+	}
+}
+
+func TestHeadlessGLLoseContext(t *testing.T) {
+	gl := NewHeadlessGL()
+	buffer := gl.NewBuffer(GlArrayBuffer, []float32{1}, GlStaticDraw)
+	gl.Enable(GlBlend)
+
+	gl.LoseContext()
+	if !gl.IsContextLost() || gl.IsEnabled(GlBlend) {
+		t.Fatal("the state survived losing the context")
+	}
+	gl.DrawArrays(GlTriangles, 0, 3)
+	if draw := gl.DrawCalls()[0]; draw.ArrayBuffer != nil {
+		t.Errorf("%v is still bound", buffer)
+	}
+
+	gl.RestoreContext()
+	if gl.IsContextLost() {
+		t.Error("the context was not restored")
+	}
+}
+
+func TestHeadlessPlatformResetsCallsEachFrame(t *testing.T) {
+	frames := 0
+	platform := startHeadless(t, &headlessApp{render: func() {
+		frames++
+		GL.Clear(GlColorBufferBit)
+	}})
+
+	if ran := platform.StepN(3, 16); ran != 3 || frames != 3 {
+		t.Fatalf("ran %d frames, rendered %d", ran, frames)
+	}
+	if clears := callsNamed(platform.GL(), "clear"); len(clears) != 1 {
+		t.Errorf("expected the calls of 1 frame, got %d clears", len(clears))
+	}
+}
+
+func TestHeadlessGLSupportedFeatures(t *testing.T) {
+	gl := NewHeadlessGL()
+	gl.SetVersion(1)
+	if !gl.Supports(FeatureElementIndexUint) || gl.Supports(FeatureTexture3D) {
+		t.Fatal("version 1 should have the extensions but not the WebGL2 features")
+	}
+
+	gl.SetSupported(FeatureElementIndexUint, false)
+	gl.SetSupported(FeatureVertexArrays, false)
+	if gl.Supports(FeatureElementIndexUint) || gl.Supports(FeatureVertexArrays) || !gl.Supports(FeatureInstancing) {
+		t.Fatal("only the features that were turned off should be unsupported")
+	}
+
+	gl.SetSupported(FeatureElementIndexUint, true)
+	if !gl.Supports(FeatureElementIndexUint) {
+		t.Fatal("the feature was not turned back on")
+	}
+}
+
+func TestHeadlessGLUnsupportedCalls(t *testing.T) {
+	gl := NewHeadlessGL()
+	gl.SetVersion(1)
+	gl.SetSupported(FeatureVertexArrays, false)
+	gl.SetSupported(FeatureInstancing, false)
+	gl.SetSupported(FeatureMultipleRenderTargets, false)
+
+	if _, err := gl.CreateVertexArray(); err != ErrNotSupported {
+		t.Errorf("CreateVertexArray returned %v", err)
+	}
+	calls := map[string]error{
+		"VertexAttribDivisor":   gl.VertexAttribDivisor(0, 1),
+		"DrawArraysInstanced":   gl.DrawArraysInstanced(GlTriangles, 0, 3, 2),
+		"DrawElementsInstanced": gl.DrawElementsInstanced(GlTriangles, 3, GlUnsignedShort, 0, 2),
+		"DrawBuffers":           gl.DrawBuffers([]GLEnum{GlColorAttachment0}),
+		"TexImage3D":            gl.TexImage3D(GlTexture2D, 0, GlRGBA, 1, 1, 1, GlRGBA, GlUnsignedByte, nil),
+	}
+	for name, err := range calls {
+		if err != ErrNotSupported {
+			t.Errorf("%s returned %v", name, err)
+		}
+	}
+	if len(gl.Calls()) != 0 || len(gl.DrawCalls()) != 0 {
+		t.Errorf("unsupported calls were recorded: %v", gl.Calls())
+	}
+
+	//The extensions are there again once they are turned back on
+	gl.SetSupported(FeatureVertexArrays, true)
+	gl.SetSupported(FeatureInstancing, true)
+	if _, err := gl.CreateVertexArray(); err != nil {
+		t.Error(err)
+	}
+	if err := gl.DrawArraysInstanced(GlTriangles, 0, 3, 2); err != nil || len(gl.DrawCalls()) != 1 {
+		t.Errorf("expected an instanced draw, got %v", err)
+	}
+}
@@ -2,36 +2,25 @@ package noodle
 
 import (
 	"errors"
-	"log"
+	"fmt"
 	"syscall/js"
 )
 
-//WebGLBuffer the js representation of a buffer
-type WebGLBuffer = js.Value
-
-//WebGLShader is a JS representation of a shader
-type WebGLShader = js.Value
-
-//WebGLShaderProgram is a JS representation of a shader program
-type WebGLShaderProgram = js.Value
-
-//WebGLUniformLocation is a JS representation of a uniform location
-type WebGLUniformLocation = js.Value
-
-//WebGLAttributeLocation is a representation of a attribute location
-type WebGLAttributeLocation = int
-
-//WebGLTexture is a JS representation of a texture
-type WebGLTexture = js.Value
-
 //WebGL is the base class that wraps GL functionality.
 type WebGL struct {
-	context js.Value
+	context    js.Value
+	version    int
+	extensions map[string]js.Value
 }
 
-func newWebGL(context js.Value) *WebGL {
+//WebGL must satisfy the GLContext
+var _ GLContext = (*WebGL)(nil)
+
+func newWebGL(context js.Value, version int) *WebGL {
 	return &WebGL{
-		context: context,
+		context:    context,
+		version:    version,
+		extensions: make(map[string]js.Value),
 	}
 }
 
@@ -65,6 +54,11 @@ func (gl *WebGL) BufferSubData(target GLEnum, offset int, data interface{}) {
 	gl.context.Call("bufferSubData", target, offset, values)
 }
 
+//DeleteBuffer deletes the buffer
+func (gl *WebGL) DeleteBuffer(buffer WebGLBuffer) {
+	gl.context.Call("deleteBuffer", buffer)
+}
+
 //CreateShader creates a new WebGLShader
 func (gl *WebGL) CreateShader(shaderType GLEnum) WebGLShader {
 	return gl.context.Call("createShader", shaderType)
@@ -79,18 +73,16 @@ func (gl *WebGL) ShaderSource(shader WebGLShader, source string) {
 func (gl *WebGL) CompileShader(shader WebGLShader) error {
 	gl.context.Call("compileShader", shader)
 
-	if !gl.GetShaderParameter(shader, GlCompileStatus).Bool() {
-		err := errors.New(gl.GetShaderInfoLog(shader))
-		log.Println("Failed to compile shader", err)
-		return err
+	if !gl.context.Call("getShaderParameter", shader, GlCompileStatus).Bool() {
+		return errors.New(gl.GetShaderInfoLog(shader))
 	}
 
 	return nil
 }
 
 //GetShaderParameter returns information about the given shader.
-func (gl *WebGL) GetShaderParameter(shader WebGLShader, param GLEnum) js.Value {
-	return gl.context.Call("getShaderParameter", shader, param)
+func (gl *WebGL) GetShaderParameter(shader WebGLShader, param GLEnum) interface{} {
+	return jsValueToGo(gl.context.Call("getShaderParameter", shader, param))
 }
 
 //GetShaderInfoLog returns the information log for the specified WebGLShader object. It contains warnings, debugging and compile information.
@@ -125,9 +117,8 @@ func (gl *WebGL) AttachShader(shaderProgram WebGLShaderProgram, shader WebGLShad
 func (gl *WebGL) LinkProgram(shaderProgram WebGLShaderProgram) error {
 	gl.context.Call("linkProgram", shaderProgram)
 
-	if !gl.GetProgramParameter(shaderProgram, GlLinkStatus).Bool() {
-		err := errors.New(gl.GetProgramInfoLog(shaderProgram))
-		return err
+	if !gl.context.Call("getProgramParameter", shaderProgram, GlLinkStatus).Bool() {
+		return errors.New(gl.GetProgramInfoLog(shaderProgram))
 	}
 
 	return nil
@@ -139,8 +130,8 @@ func (gl *WebGL) UseProgram(shaderProgram WebGLShaderProgram) {
 }
 
 //GetProgramParameter returns information about the given program.
-func (gl *WebGL) GetProgramParameter(shaderProgram WebGLShaderProgram, param GLEnum) js.Value {
-	return gl.context.Call("getProgramParameter", shaderProgram, param)
+func (gl *WebGL) GetProgramParameter(shaderProgram WebGLShaderProgram, param GLEnum) interface{} {
+	return jsValueToGo(gl.context.Call("getProgramParameter", shaderProgram, param))
 }
 
 //GetProgramInfoLog returns the information log for the specified WebGLProgram object. It contains errors that occurred during failed linking or validation of WebGLProgram objects.
@@ -169,6 +160,29 @@ func (gl *WebGL) GetAttribLocation(shaderProgram WebGLShaderProgram, attribute s
 	return gl.context.Call("getAttribLocation", shaderProgram, attribute).Int()
 }
 
+//GetActiveUniform describes one of the active uniforms of a program
+func (gl *WebGL) GetActiveUniform(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error) {
+	info := gl.context.Call("getActiveUniform", shaderProgram, index)
+	if info.IsNull() {
+		return WebGLActiveInfo{}, fmt.Errorf("uniform %d is not active", index)
+	}
+	return WebGLActiveInfo{info.Get("name").String(), info.Get("size").Int(), info.Get("type").Int()}, nil
+}
+
+//GetActiveAttrib describes one of the active attributes of a program
+func (gl *WebGL) GetActiveAttrib(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error) {
+	info := gl.context.Call("getActiveAttrib", shaderProgram, index)
+	if info.IsNull() {
+		return WebGLActiveInfo{}, fmt.Errorf("attribute %d is not active", index)
+	}
+	return WebGLActiveInfo{info.Get("name").String(), info.Get("size").Int(), info.Get("type").Int()}, nil
+}
+
+//DeleteProgram deletes the program
+func (gl *WebGL) DeleteProgram(shaderProgram WebGLShaderProgram) {
+	gl.context.Call("deleteProgram", shaderProgram)
+}
+
 //VertexAttribPointer binds the buffer currently bound to gl.ARRAY_BUFFER to a generic vertex attribute of the current vertex buffer object and specifies its layout.
 func (gl *WebGL) VertexAttribPointer(position WebGLAttributeLocation, size int, valueType GLEnum, normalized bool, stride int, offset int) {
 	gl.context.Call("vertexAttribPointer", position, size, valueType, normalized, stride, offset)
@@ -179,6 +193,16 @@ func (gl *WebGL) EnableVertexAttribArray(position WebGLAttributeLocation) {
 	gl.context.Call("enableVertexAttribArray", position)
 }
 
+//DisableVertexAttribArray turns off the generic vertex attribute array at the specified index.
+func (gl *WebGL) DisableVertexAttribArray(position WebGLAttributeLocation) {
+	gl.context.Call("disableVertexAttribArray", position)
+}
+
+//VertexAttrib4f sets the value a generic vertex attribute has when its array is turned off
+func (gl *WebGL) VertexAttrib4f(position WebGLAttributeLocation, x, y, z, w float32) {
+	gl.context.Call("vertexAttrib4f", position, x, y, z, w)
+}
+
 //ClearColor sets the colour the screen will be cleared to
 func (gl *WebGL) ClearColor(r, g, b, a float64) {
 	gl.context.Call("clearColor", float32(r), float32(g), float32(b), float32(a))
@@ -264,6 +288,11 @@ func (gl *WebGL) TexParameterf(target GLEnum, param GLEnum, value float64) {
 	gl.context.Call("texParameterf", target, param, value)
 }
 
+//DeleteTexture deletes the texture
+func (gl *WebGL) DeleteTexture(texture WebGLTexture) {
+	gl.context.Call("deleteTexture", texture)
+}
+
 //=== Uniform Setting
 
 //Uniform1f specifies values of uniform variables
@@ -316,6 +345,26 @@ func (gl *WebGL) Uniform2v(location WebGLUniformLocation, value Vector2) {
 	gl.context.Call("uniform2fv", location, tmp)
 }
 
+//Uniform3f specifies values of uniform variables
+func (gl *WebGL) Uniform3f(location WebGLUniformLocation, value, value2, value3 float32) {
+	gl.context.Call("uniform3f", location, value, value2, value3)
+}
+
+//Uniform3v is an alias of Uniform3fv but with Vector support
+func (gl *WebGL) Uniform3v(location WebGLUniformLocation, value Vector3) {
+	gl.context.Call("uniform3f", location, value.X, value.Y, value.Z)
+}
+
+//Uniform4f specifies values of uniform variables
+func (gl *WebGL) Uniform4f(location WebGLUniformLocation, value, value2, value3, value4 float32) {
+	gl.context.Call("uniform4f", location, value, value2, value3, value4)
+}
+
+//Uniform4v is an alias of Uniform4fv but with Vector support
+func (gl *WebGL) Uniform4v(location WebGLUniformLocation, value Vector4) {
+	gl.context.Call("uniform4f", location, value.X, value.Y, value.Z, value.W)
+}
+
 //UniformMatrix4fv specify matrix values for uniform variables.
 func (gl *WebGL) UniformMatrix4fv(location WebGLUniformLocation, matrix Matrix) {
 	buffer := matrix.DecomposePointer()
@@ -348,7 +397,26 @@ func (gl *WebGL) Call(m string, args ...interface{}) js.Value {
 	return gl.context.Call(m, convts...)
 }
 
+//IsContextLost checks if the context has been lost. Every object created before a loss is invalid.
+func (gl *WebGL) IsContextLost() bool {
+	return gl.context.Call("isContextLost").Bool()
+}
+
 //IsUndefined checks if the context is undefined
 func (gl *WebGL) IsUndefined() bool {
 	return gl.context.IsUndefined()
 }
+
+//jsValueToGo converts booleans, numbers and strings into their Go equivilent. Other values are left as a js.Value
+func jsValueToGo(value js.Value) interface{} {
+	switch value.Type() {
+	case js.TypeBoolean:
+		return value.Bool()
+	case js.TypeNumber:
+		return value.Int()
+	case js.TypeString:
+		return value.String()
+	default:
+		return value
+	}
+}
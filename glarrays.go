@@ -3,8 +3,6 @@ package noodle
 import (
 	"fmt"
 	"reflect"
-	"runtime"
-	"syscall/js"
 	"unsafe"
 )
 
@@ -82,87 +80,3 @@ func sliceToByteSlice(s interface{}) []byte {
 		panic(fmt.Sprintf("jsutil: unexpected value at sliceToBytesSlice: %T", s))
 	}
 }
-
-//sliceToTypedArray converts a slice of values (a buffer) into a JavaScript equivilent typed array
-func sliceToTypedArray(s interface{}) js.Value {
-	switch s := s.(type) {
-	case js.Value:
-		return s
-
-	//Bytes
-	case []int8:
-		a := js.Global().Get("Uint8Array").New(len(s))
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Int8Array").New(buf, a.Get("byteOffset"), a.Get("byteLength"))
-
-	//Ints
-	case []int16:
-		a := js.Global().Get("Uint8Array").New(len(s) * 2)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Int16Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/2)
-	case []int32:
-		a := js.Global().Get("Uint8Array").New(len(s) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Int32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-	case []uint8:
-		a := js.Global().Get("Uint8Array").New(len(s))
-		js.CopyBytesToJS(a, s)
-		runtime.KeepAlive(s)
-		return a
-	case []uint16:
-		a := js.Global().Get("Uint8Array").New(len(s) * 2)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Uint16Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/2)
-	case []uint32:
-		a := js.Global().Get("Uint8Array").New(len(s) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Uint32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-
-	//Floats
-	case []float32:
-		a := js.Global().Get("Uint8Array").New(len(s) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Float32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-	case []float64:
-		a := js.Global().Get("Uint8Array").New(len(s) * 8)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Float64Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/8)
-
-	//Vectors
-	case []Vector2:
-		a := js.Global().Get("Uint8Array").New((len(s) * 2) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Float32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-	case []Vector3:
-		a := js.Global().Get("Uint8Array").New((len(s) * 3) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Float32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-	case []Vector4:
-		a := js.Global().Get("Uint8Array").New((len(s) * 4) * 4)
-		js.CopyBytesToJS(a, sliceToByteSlice(s))
-		runtime.KeepAlive(s)
-		buf := a.Get("buffer")
-		return js.Global().Get("Float32Array").New(buf, a.Get("byteOffset"), a.Get("byteLength").Int()/4)
-
-	default:
-		panic(fmt.Sprintf("jsutil: unexpected value at SliceToTypedArray: %T", s))
-	}
-}
@@ -0,0 +1,247 @@
+package noodle
+
+import "errors"
+
+//ErrNotSupported is returned when a GL call requires a feature the context does not have
+var ErrNotSupported = errors.New("not supported by this GL context")
+
+//WebGLBuffer is a handle to a buffer owned by the GLContext
+type WebGLBuffer interface{}
+
+//WebGLShader is a handle to a shader owned by the GLContext
+type WebGLShader interface{}
+
+//WebGLShaderProgram is a handle to a shader program owned by the GLContext
+type WebGLShaderProgram interface{}
+
+//WebGLUniformLocation is a handle to a uniform location owned by the GLContext
+type WebGLUniformLocation interface{}
+
+//WebGLAttributeLocation is a representation of a attribute location
+type WebGLAttributeLocation = int
+
+//WebGLTexture is a handle to a texture owned by the GLContext
+type WebGLTexture interface{}
+
+//WebGLVertexArray is a handle to a vertex array object owned by the GLContext
+type WebGLVertexArray interface{}
+
+//WebGLFramebuffer is a handle to a framebuffer owned by the GLContext
+type WebGLFramebuffer interface{}
+
+//WebGLActiveInfo describes an active uniform or attribute of a linked program
+type WebGLActiveInfo struct {
+	Name string //Name is the name in the shader. Arrays end in [0].
+	Size int    //Size is the number of elements, which is 1 unless it is an array
+	Type GLEnum //Type is the GL type, such as GlFloatVec3 or GlSampler2d
+}
+
+//GLFeature is a capability that is only guaranteed by WebGL2, but may be available in WebGL1 through an extension
+type GLFeature int
+
+const (
+	//FeatureVertexArrays is vertex array objects. WebGL1 uses OES_vertex_array_object.
+	FeatureVertexArrays GLFeature = iota
+	//FeatureInstancing is instanced drawing. WebGL1 uses ANGLE_instanced_arrays.
+	FeatureInstancing
+	//FeatureTexture3D is 3D textures. Requires WebGL2.
+	FeatureTexture3D
+	//FeatureUniformBuffers is uniform buffer objects. Requires WebGL2.
+	FeatureUniformBuffers
+	//FeatureMultipleRenderTargets is drawing to several color attachments at once. WebGL1 uses WEBGL_draw_buffers.
+	FeatureMultipleRenderTargets
+	//FeatureElementIndexUint is drawing with 32 bit indices. WebGL1 uses OES_element_index_uint.
+	FeatureElementIndexUint
+)
+
+//GLContext describes the GL calls noodle makes. WebGL implements it for the browser, while HeadlessGL implements it in pure Go so renderers can run without one.
+type GLContext interface {
+	//Version gets the WebGL version of the context, either 1 or 2
+	Version() int
+	//Supports checks if the context can use the feature, either natively or through an extension
+	Supports(feature GLFeature) bool
+	//IsContextLost checks if the context has been lost. Every object created before a loss is invalid.
+	IsContextLost() bool
+
+	//=== Buffers
+
+	//NewBuffer creates, binds and sets the data of a new buffer
+	NewBuffer(target GLEnum, data interface{}, usage GLEnum) WebGLBuffer
+	//CreateBuffer creates a WebGLBuffer object.
+	CreateBuffer() WebGLBuffer
+	//BindBuffer binds a given WebGLBuffer to a target.
+	BindBuffer(target GLEnum, buffer WebGLBuffer)
+	//BufferData sets the data of a buffer
+	BufferData(target GLEnum, data interface{}, usage GLEnum)
+	//BufferSubData updates a subset of a buffer object's data store.
+	BufferSubData(target GLEnum, offset int, data interface{})
+	//DeleteBuffer deletes the buffer
+	DeleteBuffer(buffer WebGLBuffer)
+
+	//=== Shaders
+
+	//CreateShader creates a new WebGLShader
+	CreateShader(shaderType GLEnum) WebGLShader
+	//ShaderSource sets the shader source code
+	ShaderSource(shader WebGLShader, source string)
+	//CompileShader compiles the shader
+	CompileShader(shader WebGLShader) error
+	//GetShaderParameter returns information about the given shader.
+	GetShaderParameter(shader WebGLShader, param GLEnum) interface{}
+	//GetShaderInfoLog returns the information log for the specified WebGLShader object.
+	GetShaderInfoLog(shader WebGLShader) string
+	//NewShader creates, sources and compiles a new shader
+	NewShader(shaderType GLEnum, sourceCode string) (WebGLShader, error)
+	//DeleteShader marks a given WebGLShader object for deletion.
+	DeleteShader(shader WebGLShader)
+
+	//=== Programs
+
+	//CreateProgram creates a new webgl shader program
+	CreateProgram() WebGLShaderProgram
+	//AttachShader attaches a shader to the program
+	AttachShader(shaderProgram WebGLShaderProgram, shader WebGLShader)
+	//LinkProgram links a given WebGLProgram
+	LinkProgram(shaderProgram WebGLShaderProgram) error
+	//UseProgram tells webgl to start using this program
+	UseProgram(shaderProgram WebGLShaderProgram)
+	//GetProgramParameter returns information about the given program.
+	GetProgramParameter(shaderProgram WebGLShaderProgram, param GLEnum) interface{}
+	//GetProgramInfoLog returns the information log for the specified WebGLProgram object.
+	GetProgramInfoLog(shaderProgram WebGLShaderProgram) string
+	//NewProgram creates a new webgl shader program with some shaders and links it
+	NewProgram(shaders []WebGLShader) (WebGLShaderProgram, error)
+	//GetUniformLocation returns the location of a specific uniform variable which is part of a given WebGLProgram.
+	GetUniformLocation(shaderProgram WebGLShaderProgram, location string) WebGLUniformLocation
+	//GetAttribLocation gets a location of an attribute
+	GetAttribLocation(shaderProgram WebGLShaderProgram, attribute string) WebGLAttributeLocation
+	//GetActiveUniform describes one of the active uniforms of a program, up to the GlActiveUniforms program parameter
+	GetActiveUniform(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error)
+	//GetActiveAttrib describes one of the active attributes of a program, up to the GlActiveAttributes program parameter
+	GetActiveAttrib(shaderProgram WebGLShaderProgram, index int) (WebGLActiveInfo, error)
+	//DeleteProgram deletes the program
+	DeleteProgram(shaderProgram WebGLShaderProgram)
+
+	//=== Attributes
+
+	//VertexAttribPointer binds the buffer currently bound to gl.ARRAY_BUFFER to a generic vertex attribute and specifies its layout.
+	VertexAttribPointer(position WebGLAttributeLocation, size int, valueType GLEnum, normalized bool, stride int, offset int)
+	//EnableVertexAttribArray turns on the generic vertex attribute array at the specified index.
+	EnableVertexAttribArray(position WebGLAttributeLocation)
+	//DisableVertexAttribArray turns off the generic vertex attribute array at the specified index.
+	DisableVertexAttribArray(position WebGLAttributeLocation)
+	//VertexAttrib4f sets the value a generic vertex attribute has when its array is turned off
+	VertexAttrib4f(position WebGLAttributeLocation, x, y, z, w float32)
+
+	//=== State
+
+	//ClearColor sets the colour the screen will be cleared to
+	ClearColor(r, g, b, a float64)
+	//ClearDepth sets the z value that is set to the depth buffer every frame
+	ClearDepth(depth float64)
+	//Viewport sets the viewport
+	Viewport(x, y, width, height int)
+	//DepthFunc specifies a function that compares incoming pixel depth to the current depth buffer value.
+	DepthFunc(function GLEnum)
+	//BlendFunc specifies the pixel arithmetic used for blending
+	BlendFunc(sFactor GLEnum, gFactor GLEnum)
+	//Enable enables a option
+	Enable(option GLEnum)
+	//Disable disables a option
+	Disable(option GLEnum)
+	//Clear empties the buffers
+	Clear(option GLEnum)
+
+	//=== Drawing
+
+	//DrawElements renders primitives from array data.
+	DrawElements(mode GLEnum, count int, valueType GLEnum, offset int)
+	//DrawArrays renders primitives from the bound array buffers.
+	DrawArrays(mode GLEnum, first int, count int)
+
+	//=== Textures
+
+	//CreateTexture creates a new texture on the GPU
+	CreateTexture() WebGLTexture
+	//BindTexture binds a given WebGLTexture to a target (binding point).
+	BindTexture(target GLEnum, texture WebGLTexture)
+	//UnbindTexture unbinds the target texture.
+	UnbindTexture(target GLEnum)
+	//ActiveTexture tells WebGL what texture state will be now modified
+	ActiveTexture(target GLEnum)
+	//TexImage2D specifies a 2D image
+	TexImage2D(target GLEnum, level int, internalFormat GLEnum, format GLEnum, texelType GLEnum, pixels interface{})
+	//GenerateMipmap creats the Mipmap for a texture
+	GenerateMipmap(target GLEnum)
+	//TexParameteri set texture parameters
+	TexParameteri(target GLEnum, param GLEnum, value int)
+	//TexParameterf set texture parameters
+	TexParameterf(target GLEnum, param GLEnum, value float64)
+	//DeleteTexture deletes the texture
+	DeleteTexture(texture WebGLTexture)
+
+	//=== Uniforms
+
+	//Uniform1f specifies values of uniform variables
+	Uniform1f(location WebGLUniformLocation, value float32)
+	//Uniform1fv specifies values of uniform variables
+	Uniform1fv(location WebGLUniformLocation, value []float32)
+	//Uniform1i specifies values of uniform variables
+	Uniform1i(location WebGLUniformLocation, value int)
+	//Uniform1iv specifies values of uniform variables
+	Uniform1iv(location WebGLUniformLocation, value []int)
+	//Uniform2f specifies values of uniform variables
+	Uniform2f(location WebGLUniformLocation, value, value2 float32)
+	//Uniform2fv specifies values of uniform variables
+	Uniform2fv(location WebGLUniformLocation, value []float32)
+	//Uniform2i specifies values of uniform variables
+	Uniform2i(location WebGLUniformLocation, value, value2 int)
+	//Uniform2iv specifies values of uniform variables
+	Uniform2iv(location WebGLUniformLocation, value []int)
+	//Uniform2v is an alias of Uniform2fv but with Vector support
+	Uniform2v(location WebGLUniformLocation, value Vector2)
+	//Uniform3f specifies values of uniform variables
+	Uniform3f(location WebGLUniformLocation, value, value2, value3 float32)
+	//Uniform3v is an alias of Uniform3fv but with Vector support
+	Uniform3v(location WebGLUniformLocation, value Vector3)
+	//Uniform4f specifies values of uniform variables
+	Uniform4f(location WebGLUniformLocation, value, value2, value3, value4 float32)
+	//Uniform4v is an alias of Uniform4fv but with Vector support
+	Uniform4v(location WebGLUniformLocation, value Vector4)
+	//UniformMatrix4fv specify matrix values for uniform variables.
+	UniformMatrix4fv(location WebGLUniformLocation, matrix Matrix)
+
+	//=== Framebuffers
+
+	//CreateFramebuffer creates a new framebuffer
+	CreateFramebuffer() WebGLFramebuffer
+	//BindFramebuffer binds the framebuffer to the target. A nil framebuffer binds the canvas.
+	BindFramebuffer(target GLEnum, framebuffer WebGLFramebuffer)
+	//FramebufferTexture2D attaches a texture to the bound framebuffer
+	FramebufferTexture2D(target GLEnum, attachment GLEnum, textarget GLEnum, texture WebGLTexture, level int)
+
+	//=== WebGL2
+
+	//CreateVertexArray creates a new vertex array object
+	CreateVertexArray() (WebGLVertexArray, error)
+	//BindVertexArray binds the vertex array object. A nil array binds the default.
+	BindVertexArray(vertexArray WebGLVertexArray)
+	//DeleteVertexArray deletes the vertex array object
+	DeleteVertexArray(vertexArray WebGLVertexArray)
+	//VertexAttribDivisor sets how many instances are drawn before the attribute advances
+	VertexAttribDivisor(position WebGLAttributeLocation, divisor int) error
+	//DrawArraysInstanced renders several instances of the primitives from the bound array buffers
+	DrawArraysInstanced(mode GLEnum, first int, count int, instances int) error
+	//DrawElementsInstanced renders several instances of the primitives from array data
+	DrawElementsInstanced(mode GLEnum, count int, valueType GLEnum, offset int, instances int) error
+	//TexImage3D specifies a 3D or array image
+	TexImage3D(target GLEnum, level int, internalFormat GLEnum, width, height, depth int, format GLEnum, texelType GLEnum, pixels interface{}) error
+	//GetUniformBlockIndex gets the index of a uniform block in the program
+	GetUniformBlockIndex(shaderProgram WebGLShaderProgram, name string) (int, error)
+	//UniformBlockBinding assigns a binding point to a uniform block
+	UniformBlockBinding(shaderProgram WebGLShaderProgram, blockIndex int, binding int) error
+	//BindBufferBase binds a buffer to an indexed binding point, such as a uniform buffer binding
+	BindBufferBase(target GLEnum, index int, buffer WebGLBuffer) error
+	//DrawBuffers sets which color attachments are drawn to
+	DrawBuffers(buffers []GLEnum) error
+}
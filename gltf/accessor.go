@@ -0,0 +1,298 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+//The component types of an accessor, which are the GL types
+const (
+	componentByte          = 5120
+	componentUnsignedByte  = 5121
+	componentShort         = 5122
+	componentUnsignedShort = 5123
+	componentUnsignedInt   = 5125
+	componentFloat         = 5126
+)
+
+//accessor describes typed data in a buffer view
+type accessor struct {
+	BufferView    *int    `json:"bufferView"`
+	ByteOffset    int     `json:"byteOffset"`
+	ComponentType int     `json:"componentType"`
+	Normalized    bool    `json:"normalized"`
+	Count         int     `json:"count"`
+	Type          string  `json:"type"`
+	Sparse        *sparse `json:"sparse"`
+}
+
+//sparse replaces some of the elements of an accessor
+type sparse struct {
+	Count   int `json:"count"`
+	Indices struct {
+		BufferView    int `json:"bufferView"`
+		ByteOffset    int `json:"byteOffset"`
+		ComponentType int `json:"componentType"`
+	} `json:"indices"`
+	Values struct {
+		BufferView int `json:"bufferView"`
+		ByteOffset int `json:"byteOffset"`
+	} `json:"values"`
+}
+
+//bufferView is a slice of a buffer
+type bufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	ByteStride int `json:"byteStride"`
+}
+
+//accessorComponents is the number of components for each accessor type
+var accessorComponents = map[string]int{
+	"SCALAR": 1,
+	"VEC2":   2,
+	"VEC3":   3,
+	"VEC4":   4,
+	"MAT2":   4,
+	"MAT3":   9,
+	"MAT4":   16,
+}
+
+//componentSize gets the size in bytes of a component type, or 0 if it is not valid
+func componentSize(componentType int) int {
+	switch componentType {
+	case componentByte, componentUnsignedByte:
+		return 1
+	case componentShort, componentUnsignedShort:
+		return 2
+	case componentUnsignedInt, componentFloat:
+		return 4
+	default:
+		return 0
+	}
+}
+
+//readComponent reads a single component. Normalized integers are scaled to 0 to 1, or -1 to 1 if they are signed.
+func readComponent(data []byte, componentType int, normalized bool) float64 {
+	switch componentType {
+	case componentByte:
+		value := float64(int8(data[0]))
+		if normalized {
+			return math.Max(value/127, -1)
+		}
+		return value
+	case componentUnsignedByte:
+		value := float64(data[0])
+		if normalized {
+			return value / 255
+		}
+		return value
+	case componentShort:
+		value := float64(int16(binary.LittleEndian.Uint16(data)))
+		if normalized {
+			return math.Max(value/32767, -1)
+		}
+		return value
+	case componentUnsignedShort:
+		value := float64(binary.LittleEndian.Uint16(data))
+		if normalized {
+			return value / 65535
+		}
+		return value
+	case componentUnsignedInt:
+		value := float64(binary.LittleEndian.Uint32(data))
+		if normalized {
+			return value / math.MaxUint32
+		}
+		return value
+	default:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+	}
+}
+
+//elementStride gets the distance between elements, which is the size of an element if the view does not set one
+func elementStride(stride, components, componentType int) int {
+	if stride == 0 {
+		return componentSize(componentType) * components
+	}
+	return stride
+}
+
+//checkElements makes sure the elements fit in the data of a view. Every element takes at least a byte, so the sizes
+// are checked against the data before they are multiplied and cannot overflow.
+func checkElements(data []byte, offset, stride, count, components, componentType int) error {
+	stride = elementStride(stride, components, componentType)
+	if offset < 0 || offset > len(data) || stride < 0 || count < 0 || count > len(data) || (count > 1 && stride > len(data)) ||
+		(count > 0 && offset+(count-1)*stride+components*componentSize(componentType) > len(data)) {
+		return fmt.Errorf("%d elements do not fit in the buffer view", count)
+	}
+	return nil
+}
+
+//readElements reads the elements of a view one component at a time
+func readElements(data []byte, offset, stride, count, components, componentType int, normalized bool, fn func(element, component int, value float64)) error {
+	if err := checkElements(data, offset, stride, count, components, componentType); err != nil {
+		return err
+	}
+
+	size := componentSize(componentType)
+	stride = elementStride(stride, components, componentType)
+	for element := 0; element < count; element++ {
+		start := offset + element*stride
+		for component := 0; component < components; component++ {
+			fn(element, component, readComponent(data[start+component*size:], componentType, normalized))
+		}
+	}
+	return nil
+}
+
+//maxAccessorValues limits the values of an accessor without a buffer view, which has no data to check its count against
+const maxAccessorValues = math.MaxInt32
+
+//accessor gets an accessor, making sure it is valid and its elements fit in its buffer view, and the number of
+// components in each of its elements. Callers can allocate for its elements once it has been checked.
+func (p *parser) accessor(index int) (accessor, int, error) {
+	if err := checkIndex("accessor", index, len(p.doc.Accessors)); err != nil {
+		return accessor{}, 0, err
+	}
+	acc := p.doc.Accessors[index]
+
+	components := accessorComponents[acc.Type]
+	if components == 0 {
+		return acc, 0, fmt.Errorf("accessor %d has an unknown type %q", index, acc.Type)
+	}
+	if componentSize(acc.ComponentType) == 0 {
+		return acc, 0, fmt.Errorf("accessor %d has an unknown component type %d", index, acc.ComponentType)
+	}
+	if acc.Count < 0 {
+		return acc, 0, fmt.Errorf("accessor %d has a negative count", index)
+	}
+
+	if acc.BufferView == nil {
+		if acc.Count > maxAccessorValues/components {
+			return acc, 0, fmt.Errorf("accessor %d has too many elements", index)
+		}
+		return acc, components, nil
+	}
+	data, err := p.bufferViewData(*acc.BufferView)
+	if err != nil {
+		return acc, 0, err
+	}
+	stride := p.doc.BufferViews[*acc.BufferView].ByteStride
+	if err := checkElements(data, acc.ByteOffset, stride, acc.Count, components, acc.ComponentType); err != nil {
+		return acc, 0, fmt.Errorf("accessor %d: %v", index, err)
+	}
+	return acc, components, nil
+}
+
+//readAccessor reads every component of an accessor. Accessors without a buffer view are all zero, and sparse values
+// are applied over the top.
+func (p *parser) readAccessor(index int, fn func(element, component int, value float64)) error {
+	acc, components, err := p.accessor(index)
+	if err != nil {
+		return err
+	}
+
+	if acc.BufferView == nil {
+		for element := 0; element < acc.Count; element++ {
+			for component := 0; component < components; component++ {
+				fn(element, component, 0)
+			}
+		}
+	} else {
+		data, err := p.bufferViewData(*acc.BufferView)
+		if err != nil {
+			return err
+		}
+		stride := p.doc.BufferViews[*acc.BufferView].ByteStride
+		if err := readElements(data, acc.ByteOffset, stride, acc.Count, components, acc.ComponentType, acc.Normalized, fn); err != nil {
+			return fmt.Errorf("accessor %d: %v", index, err)
+		}
+	}
+
+	if acc.Sparse != nil && acc.Sparse.Count > 0 {
+		if err := p.readSparse(acc, components, fn); err != nil {
+			return fmt.Errorf("accessor %d: %v", index, err)
+		}
+	}
+	return nil
+}
+
+//readSparse reads the replacement values of a sparse accessor
+func (p *parser) readSparse(acc accessor, components int, fn func(element, component int, value float64)) error {
+	s := acc.Sparse
+	if componentSize(s.Indices.ComponentType) == 0 || s.Indices.ComponentType == componentFloat {
+		return fmt.Errorf("sparse indices have an invalid component type %d", s.Indices.ComponentType)
+	}
+
+	if s.Count > acc.Count {
+		return fmt.Errorf("%d sparse values are more than the %d elements", s.Count, acc.Count)
+	}
+
+	indexData, err := p.bufferViewData(s.Indices.BufferView)
+	if err != nil {
+		return err
+	}
+	if err := checkElements(indexData, s.Indices.ByteOffset, 0, s.Count, 1, s.Indices.ComponentType); err != nil {
+		return err
+	}
+	indices := make([]int, s.Count)
+	err = readElements(indexData, s.Indices.ByteOffset, 0, s.Count, 1, s.Indices.ComponentType, false, func(element, _ int, value float64) {
+		indices[element] = int(value)
+	})
+	if err != nil {
+		return err
+	}
+	for _, element := range indices {
+		if element < 0 || element >= acc.Count {
+			return fmt.Errorf("sparse index %d is out of range", element)
+		}
+	}
+
+	valueData, err := p.bufferViewData(s.Values.BufferView)
+	if err != nil {
+		return err
+	}
+	return readElements(valueData, s.Values.ByteOffset, 0, s.Count, components, acc.ComponentType, acc.Normalized, func(element, component int, value float64) {
+		fn(indices[element], component, value)
+	})
+}
+
+//readFloats reads an accessor into a flat slice of floats, returning the number of components in each element
+func (p *parser) readFloats(index int) ([]float32, int, error) {
+	acc, components, err := p.accessor(index)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values := make([]float32, acc.Count*components)
+	err = p.readAccessor(index, func(element, component int, value float64) {
+		values[element*components+component] = float32(value)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return values, components, nil
+}
+
+//readIndices reads a scalar accessor of unsigned integers
+func (p *parser) readIndices(index int) ([]uint32, error) {
+	acc, _, err := p.accessor(index)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Type != "SCALAR" || acc.Normalized || acc.ComponentType == componentFloat {
+		return nil, fmt.Errorf("accessor %d is not a list of indices", index)
+	}
+
+	indices := make([]uint32, acc.Count)
+	err = p.readAccessor(index, func(element, _ int, value float64) {
+		indices[element] = uint32(value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
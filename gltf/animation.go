@@ -0,0 +1,192 @@
+package gltf
+
+import (
+	"fmt"
+
+	"github.com/lachee/noodle"
+)
+
+//Path is the property of a node that an animation channel changes
+type Path string
+
+const (
+	PathTranslation Path = "translation" //PathTranslation animates the position
+	PathRotation    Path = "rotation"    //PathRotation animates the rotation
+	PathScale       Path = "scale"       //PathScale animates the scale
+	PathWeights     Path = "weights"     //PathWeights animates morph target weights, which are not applied by Animation.Apply
+)
+
+//Interpolation is how the values between keyframes are calculated
+type Interpolation string
+
+const (
+	InterpolationLinear      Interpolation = "LINEAR"      //InterpolationLinear lerps between keyframes, and slerps rotations
+	InterpolationStep        Interpolation = "STEP"        //InterpolationStep holds each keyframe until the next
+	InterpolationCubicSpline Interpolation = "CUBICSPLINE" //InterpolationCubicSpline is a hermite spline with tangents stored in the keyframes
+)
+
+//Animation is a set of channels that play together
+type Animation struct {
+	Name     string
+	Channels []*Channel
+	Duration float32 //Duration is the time of the last keyframe of any channel, in seconds
+}
+
+//Channel animates a single property of a node
+type Channel struct {
+	Node          int //Node is the index of the node that is animated
+	Path          Path
+	Interpolation Interpolation
+
+	Times []float32 //Times are the time of each keyframe, in seconds
+
+	//Values are the values of each keyframe, each of Components floats. Cubic splines store an in-tangent, value and
+	// out-tangent for each keyframe, in that order.
+	Values     []float32
+	Components int
+}
+
+func (p *parser) animations(model *Model) error {
+	for i, source := range p.doc.Animations {
+		animation := &Animation{Name: source.Name}
+		for j, c := range source.Channels {
+			if c.Target.Node == nil {
+				continue //The channel targets something from an extension
+			}
+			if err := checkIndex("node", *c.Target.Node, len(p.doc.Nodes)); err != nil {
+				return err
+			}
+			if err := checkIndex("sampler", c.Sampler, len(source.Samplers)); err != nil {
+				return fmt.Errorf("animation %d channel %d: %v", i, j, err)
+			}
+
+			sampler := source.Samplers[c.Sampler]
+			channel := &Channel{
+				Node:          *c.Target.Node,
+				Path:          Path(c.Target.Path),
+				Interpolation: Interpolation(sampler.Interpolation),
+			}
+			if channel.Interpolation == "" {
+				channel.Interpolation = InterpolationLinear
+			}
+
+			var err error
+			if channel.Times, _, err = p.readFloats(sampler.Input); err != nil {
+				return fmt.Errorf("animation %d channel %d: %v", i, j, err)
+			}
+			if channel.Values, channel.Components, err = p.readFloats(sampler.Output); err != nil {
+				return fmt.Errorf("animation %d channel %d: %v", i, j, err)
+			}
+
+			//Morph target weights are scalars, with as many for each keyframe as there are targets
+			keyframes := len(channel.Times)
+			if channel.Interpolation == InterpolationCubicSpline {
+				keyframes *= 3
+			}
+			if channel.Path == PathWeights && keyframes > 0 {
+				channel.Components = len(channel.Values) / keyframes
+			}
+			if len(channel.Values) != keyframes*channel.Components {
+				return fmt.Errorf("animation %d channel %d does not have a value for every keyframe", i, j)
+			}
+
+			if keyframes > 0 && channel.Times[len(channel.Times)-1] > animation.Duration {
+				animation.Duration = channel.Times[len(channel.Times)-1]
+			}
+			animation.Channels = append(animation.Channels, channel)
+		}
+		model.Animations = append(model.Animations, animation)
+	}
+	return nil
+}
+
+//value gets the value of a keyframe. For cubic splines, part is 0 for the in-tangent, 1 for the value and 2 for the out-tangent.
+func (c *Channel) value(keyframe, part int) []float32 {
+	if c.Interpolation == InterpolationCubicSpline {
+		keyframe = keyframe*3 + part
+	}
+	return c.Values[keyframe*c.Components : (keyframe+1)*c.Components]
+}
+
+//Sample gets the value of the channel at the time in seconds. Times before the first keyframe or after the last are
+// clamped. Rotations are normalized quaternions.
+func (c *Channel) Sample(time float32) []float32 {
+	if len(c.Times) == 0 {
+		return nil
+	}
+
+	result := make([]float32, c.Components)
+	last := len(c.Times) - 1
+	if time <= c.Times[0] || last == 0 {
+		copy(result, c.value(0, 1))
+		return result
+	}
+	if time >= c.Times[last] {
+		copy(result, c.value(last, 1))
+		return result
+	}
+
+	//Find the keyframe before the time
+	key := 0
+	for key+1 < last && c.Times[key+1] <= time {
+		key++
+	}
+
+	delta := c.Times[key+1] - c.Times[key]
+	t := (time - c.Times[key]) / delta
+	switch c.Interpolation {
+	case InterpolationStep:
+		copy(result, c.value(key, 1))
+		return result
+
+	case InterpolationCubicSpline:
+		t2, t3 := t*t, t*t*t
+		from, outTangent := c.value(key, 1), c.value(key, 2)
+		to, inTangent := c.value(key+1, 1), c.value(key+1, 0)
+		for i := range result {
+			result[i] = (2*t3-3*t2+1)*from[i] + (t3-2*t2+t)*delta*outTangent[i] + (-2*t3+3*t2)*to[i] + (t3-t2)*delta*inTangent[i]
+		}
+		if c.Path == PathRotation && c.Components == 4 {
+			q := quaternion(result).Normalize()
+			copy(result, []float32{q.X, q.Y, q.Z, q.W})
+		}
+		return result
+
+	default:
+		from, to := c.value(key, 1), c.value(key+1, 1)
+		if c.Path == PathRotation && c.Components == 4 {
+			q := quaternion(from).Slerp(quaternion(to), t).Normalize()
+			copy(result, []float32{q.X, q.Y, q.Z, q.W})
+			return result
+		}
+		for i := range result {
+			result[i] = from[i] + (to[i]-from[i])*t
+		}
+		return result
+	}
+}
+
+//quaternion reads an x, y, z, w quaternion
+func quaternion(values []float32) noodle.Quaternion {
+	return noodle.Quaternion{X: values[0], Y: values[1], Z: values[2], W: values[3]}
+}
+
+//Apply sets the transforms of the animated nodes in the model to how they are at the time in seconds
+func (a *Animation) Apply(model *Model, time float32) {
+	for _, channel := range a.Channels {
+		if channel.Node < 0 || channel.Node >= len(model.Nodes) {
+			continue
+		}
+
+		node := model.Nodes[channel.Node]
+		value := channel.Sample(time)
+		switch {
+		case channel.Path == PathTranslation && len(value) == 3:
+			node.Transform.Position = noodle.Vector3{X: value[0], Y: value[1], Z: value[2]}
+		case channel.Path == PathRotation && len(value) == 4:
+			node.Transform.Rotation = quaternion(value)
+		case channel.Path == PathScale && len(value) == 3:
+			node.Transform.Scale = noodle.Vector3{X: value[0], Y: value[1], Z: value[2]}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package gltf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lachee/noodle"
+)
+
+//yaw is a rotation of the angle in degrees about Y
+func yaw(degrees float64) noodle.Quaternion {
+	half := degrees * math.Pi / 360
+	return noodle.Quaternion{Y: float32(math.Sin(half)), W: float32(math.Cos(half))}
+}
+
+//near checks if the values are within the tolerance
+func near(values []float32, expected ...float32) bool {
+	if len(values) != len(expected) {
+		return false
+	}
+	for i := range values {
+		if math.Abs(float64(values[i]-expected[i])) > 1e-4 {
+			return false
+		}
+	}
+	return true
+}
+
+//parseAnimated parses a root with a joint under it, which are animated and skinned.
+// The root's translation steps, the joint's rotation is linear and its scale is a cubic spline.
+func parseAnimated(t *testing.T) *Model {
+	t.Helper()
+	quarter, half := yaw(90), yaw(180)
+	ibm := noodle.NewMatrixTranslate(noodle.Vector3{X: -1}).Decompose()
+	values := []float32{
+		0, 1, 2, //times at 0
+		0, 0, 0, 2, 0, 0, 2, 4, 0, //translations at 12
+		0, 0, 0, 1, quarter.X, quarter.Y, quarter.Z, quarter.W, half.X, half.Y, half.Z, half.W, //rotations at 48
+		0, 0, 0, 1, 1, 1, 3, 3, 3, 0, 0, 0, 2, 2, 2, 0, 0, 0, //scale in-tangents, values and out-tangents at 96
+		1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, //identity inverse bind matrix at 168
+	}
+	values = append(values, ibm[:]...)
+
+	return parseJSON(t, `
+		"nodes": [{"name": "root", "children": [1]}, {"name": "joint", "translation": [1, 0, 0]}, {"name": "skinned", "skin": 0}],
+		"skins": [{"joints": [0, 1], "inverseBindMatrices": 5, "skeleton": 0}],
+		"animations": [{
+			"name": "walk",
+			"channels": [
+				{"sampler": 0, "target": {"node": 0, "path": "translation"}},
+				{"sampler": 1, "target": {"node": 1, "path": "rotation"}},
+				{"sampler": 2, "target": {"node": 1, "path": "scale"}}
+			],
+			"samplers": [
+				{"input": 0, "output": 1, "interpolation": "STEP"},
+				{"input": 0, "output": 2},
+				{"input": 3, "output": 4, "interpolation": "CUBICSPLINE"}
+			]
+		}],
+		"accessors": [
+			{"bufferView": 0, "componentType": 5126, "count": 3, "type": "SCALAR"},
+			{"bufferView": 0, "byteOffset": 12, "componentType": 5126, "count": 3, "type": "VEC3"},
+			{"bufferView": 0, "byteOffset": 48, "componentType": 5126, "count": 3, "type": "VEC4"},
+			{"bufferView": 0, "componentType": 5126, "count": 2, "type": "SCALAR"},
+			{"bufferView": 0, "byteOffset": 96, "componentType": 5126, "count": 6, "type": "VEC3"},
+			{"bufferView": 0, "byteOffset": 168, "componentType": 5126, "count": 2, "type": "MAT4"}
+		],
+		"bufferViews": [{"buffer": 0, "byteLength": 296}],
+		"buffers": [`+floatBuffer(values...)+`]`)
+}
+
+func TestChannelSampleStep(t *testing.T) {
+	channel := parseAnimated(t).Animations[0].Channels[0]
+	if channel.Interpolation != InterpolationStep || channel.Components != 3 {
+		t.Fatalf("unexpected channel %+v", channel)
+	}
+
+	//Each keyframe is held until the next, and times outside the keyframes are clamped
+	samples := map[float32][]float32{-1: {0, 0, 0}, 0.5: {0, 0, 0}, 1: {2, 0, 0}, 1.99: {2, 0, 0}, 5: {2, 4, 0}}
+	for time, expected := range samples {
+		if value := channel.Sample(time); !near(value, expected...) {
+			t.Errorf("at %v: expected %v, got %v", time, expected, value)
+		}
+	}
+}
+
+func TestChannelSampleLinearRotation(t *testing.T) {
+	channel := parseAnimated(t).Animations[0].Channels[1]
+	if channel.Interpolation != InterpolationLinear {
+		t.Fatalf("expected linear to be the default, got %v", channel.Interpolation)
+	}
+
+	//Rotations are slerped, so half way between keyframes is half the angle
+	samples := map[float32]float64{0: 0, 0.5: 45, 1: 90, 1.5: 135, 2: 180}
+	for time, angle := range samples {
+		q := yaw(angle)
+		if value := channel.Sample(time); !near(value, q.X, q.Y, q.Z, q.W) {
+			t.Errorf("at %v: expected %v, got %v", time, q, value)
+		}
+	}
+}
+
+func TestChannelSampleCubicSpline(t *testing.T) {
+	channel := parseAnimated(t).Animations[0].Channels[2]
+	if channel.Interpolation != InterpolationCubicSpline || len(channel.Values) != 18 {
+		t.Fatalf("unexpected channel %+v", channel)
+	}
+
+	//The out-tangent of the first keyframe pulls the curve above a straight line
+	if value := channel.Sample(0.5); !near(value, 1.875, 1.875, 1.875) {
+		t.Errorf("expected 1.875 half way, got %v", value)
+	}
+	if value := channel.Sample(0); !near(value, 1, 1, 1) {
+		t.Errorf("expected the first value at the start, got %v", value)
+	}
+	if value := channel.Sample(1); !near(value, 2, 2, 2) {
+		t.Errorf("expected the last value at the end, got %v", value)
+	}
+}
+
+func TestAnimationApply(t *testing.T) {
+	model := parseAnimated(t)
+	animation := model.Animations[0]
+	if animation.Name != "walk" || animation.Duration != 2 || len(animation.Channels) != 3 {
+		t.Fatalf("unexpected animation %+v", animation)
+	}
+
+	animation.Apply(model, 1.5)
+	root, joint := model.Nodes[0].Transform, model.Nodes[1].Transform
+	q := yaw(135)
+	if root.Position != (noodle.Vector3{X: 2}) {
+		t.Errorf("expected the root to step to (2, 0, 0), got %v", root.Position)
+	}
+	if !near([]float32{joint.Rotation.X, joint.Rotation.Y, joint.Rotation.Z, joint.Rotation.W}, q.X, q.Y, q.Z, q.W) {
+		t.Errorf("expected the joint to turn to %v, got %v", q, joint.Rotation)
+	}
+	if joint.Scale != (noodle.Vector3{X: 2, Y: 2, Z: 2}) || joint.Position != (noodle.Vector3{X: 1}) {
+		t.Errorf("expected the joint to be scaled to 2 and stay in place, got %+v", joint)
+	}
+}
+
+func TestSkinJointMatrices(t *testing.T) {
+	model := parseAnimated(t)
+	skin := model.Skins[0]
+	if len(skin.Joints) != 2 || skin.Skeleton != 0 || model.Nodes[2].Skin != 0 {
+		t.Fatalf("unexpected skin %+v", skin)
+	}
+
+	//In the bind pose the joint is where its inverse bind matrix expects, so nothing moves
+	model.Animations[0].Apply(model, 0)
+	for i, matrix := range model.JointMatrices(0) {
+		if point := (noodle.Vector3{X: 1, Y: 2, Z: 3}).Transform(matrix); !near([]float32{point.X, point.Y, point.Z}, 1, 2, 3) {
+			t.Errorf("joint %d moved a vertex to %v in the bind pose", i, point)
+		}
+	}
+
+	//At 1 second the root has moved 2 along X, and the joint has turned a quarter and doubled in size
+	model.Animations[0].Apply(model, 1)
+	matrices := model.JointMatrices(0)
+	points := []struct{ bind, posed noodle.Vector3 }{
+		{noodle.Vector3{X: 1}, noodle.Vector3{X: 3}},
+		{noodle.Vector3{X: 2}, noodle.Vector3{X: 3, Z: -2}},
+	}
+	for _, p := range points {
+		if posed := p.bind.Transform(matrices[1]); !near([]float32{posed.X, posed.Y, posed.Z}, p.posed.X, p.posed.Y, p.posed.Z) {
+			t.Errorf("expected the joint to move %v to %v, got %v", p.bind, p.posed, posed)
+		}
+	}
+}
@@ -0,0 +1,808 @@
+//Package gltf loads glTF 2.0 models, both the JSON .gltf files and binary .glb files, into noodle meshes.
+//
+//Parsing is pure Go and does not need a GL context. The meshes, images and animations are read into memory, and
+// uploading them to the GPU is a separate step with Model.Upload and Model.LoadTexture.
+//
+//glTF uses the same right handed, Y up coordinates and column major matrices as noodle, and its UVs start from the
+// top left of the texture, so nothing is flipped.
+package gltf
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lachee/noodle"
+)
+
+var (
+	//ErrInvalidGLB is returned when a binary file does not have a valid header or chunks
+	ErrInvalidGLB = errors.New("invalid glb file")
+	//ErrUnsupportedVersion is returned when the file is not glTF 2.0
+	ErrUnsupportedVersion = errors.New("only glTF 2.0 is supported")
+	//ErrExternalResource is returned when the file refers to another file but there is no Resolver to load it
+	ErrExternalResource = errors.New("the file uses external resources but no resolver was given")
+)
+
+//Resolver loads a file the model refers to, such as a .bin buffer or a texture. The URI is as written in the file,
+// relative to the model.
+type Resolver func(uri string) ([]byte, error)
+
+//Model is a parsed glTF file. Objects refer to each other by their index in the model, and -1 means none.
+type Model struct {
+	Scenes     []*Scene
+	Scene      int //Scene is the scene to show by default. It is the first scene if the file did not say, or -1 if there are none.
+	Nodes      []*Node
+	Meshes     []*Mesh
+	Materials  []*Material
+	Textures   []*Texture
+	Images     []*Image
+	Skins      []*Skin
+	Animations []*Animation
+}
+
+//Scene is a set of root nodes
+type Scene struct {
+	Name  string
+	Nodes []int //Nodes are the roots of the scene
+}
+
+//Node is part of the hierarchy. It can have a mesh, and a skin that deforms it.
+type Node struct {
+	Name      string
+	Parent    int   //Parent is the index of the parent node, or -1 if it is a root
+	Children  []int //Children are the indices of the child nodes
+	Transform noodle.Transform
+	Mesh      int //Mesh is the index of the mesh, or -1
+	Skin      int //Skin is the index of the skin, or -1
+}
+
+//Mesh is a set of primitives that are drawn together
+type Mesh struct {
+	Name       string
+	Primitives []*Primitive
+}
+
+//Primitive is part of a mesh that is drawn with a single material
+type Primitive struct {
+	Mesh     *noodle.Mesh //Mesh has the geometry. Only the first set of UVs and colours are loaded. It has not been uploaded yet.
+	Material int          //Material is the index of the material, or -1 for the default material
+
+	Joints  [][4]uint16      //Joints are the JOINTS_0 indices into the skin's joints, for each vertex. It is empty if the primitive is not skinned.
+	Weights []noodle.Vector4 //Weights are the WEIGHTS_0 of each joint, for each vertex
+}
+
+//AlphaMode is how the alpha of a material is used
+type AlphaMode string
+
+const (
+	AlphaOpaque AlphaMode = "OPAQUE" //AlphaOpaque ignores the alpha
+	AlphaMask   AlphaMode = "MASK"   //AlphaMask draws pixels whose alpha is at least the AlphaCutoff
+	AlphaBlend  AlphaMode = "BLEND"  //AlphaBlend blends with what is behind
+)
+
+//TextureInfo is a reference from a material to a texture
+type TextureInfo struct {
+	Texture  int     //Texture is the index of the texture
+	TexCoord int     //TexCoord is the set of UVs the texture uses. Only the first set is loaded into meshes.
+	Scale    float32 //Scale is the scale of a normal texture or the strength of an occlusion texture. It is 1 for other textures.
+}
+
+//Material is a physically based metallic-roughness material. Textures are nil if not set.
+type Material struct {
+	Name string
+
+	BaseColor                noodle.Vector4 //BaseColor is the linear base colour, multiplied with the texture
+	BaseColorTexture         *TextureInfo
+	Metallic                 float32 //Metallic is multiplied with the blue channel of the metallic-roughness texture
+	Roughness                float32 //Roughness is multiplied with the green channel of the metallic-roughness texture
+	MetallicRoughnessTexture *TextureInfo
+
+	NormalTexture    *TextureInfo
+	OcclusionTexture *TextureInfo
+	Emissive         noodle.Vector3 //Emissive is the linear emitted colour, multiplied with the texture
+	EmissiveTexture  *TextureInfo
+
+	AlphaMode   AlphaMode
+	AlphaCutoff float32
+	DoubleSided bool
+}
+
+//Texture is an image and how it is sampled
+type Texture struct {
+	Name      string
+	Image     int //Image is the index of the image, or -1
+	MagFilter noodle.TextureFilter
+	MinFilter noodle.TextureFilter
+	WrapS     noodle.TextureWrap
+	WrapT     noodle.TextureWrap
+}
+
+//Image is the encoded data of an image, such as a PNG or JPEG
+type Image struct {
+	Name     string
+	URI      string //URI is where the image was loaded from, as written in the file. It is empty if the image is embedded.
+	MimeType string
+	Data     []byte
+}
+
+//Skin is a set of joints that deform the vertices of a mesh
+type Skin struct {
+	Name                string
+	Joints              []int           //Joints are the indices of the nodes that are the joints
+	InverseBindMatrices []noodle.Matrix //InverseBindMatrices move a vertex into the space of each joint
+	Skeleton            int             //Skeleton is the index of the common root of the joints, or -1
+}
+
+//=== JSON
+
+//document is the JSON part of the file
+type document struct {
+	Asset struct {
+		Version    string `json:"version"`
+		MinVersion string `json:"minVersion"`
+	} `json:"asset"`
+	Scene  *int `json:"scene"`
+	Scenes []struct {
+		Name  string `json:"name"`
+		Nodes []int  `json:"nodes"`
+	} `json:"scenes"`
+	Nodes []struct {
+		Name        string    `json:"name"`
+		Children    []int     `json:"children"`
+		Mesh        *int      `json:"mesh"`
+		Skin        *int      `json:"skin"`
+		Matrix      []float32 `json:"matrix"`
+		Translation []float32 `json:"translation"`
+		Rotation    []float32 `json:"rotation"`
+		Scale       []float32 `json:"scale"`
+	} `json:"nodes"`
+	Meshes []struct {
+		Name       string `json:"name"`
+		Primitives []struct {
+			Attributes map[string]int `json:"attributes"`
+			Indices    *int           `json:"indices"`
+			Material   *int           `json:"material"`
+			Mode       *int           `json:"mode"`
+		} `json:"primitives"`
+	} `json:"meshes"`
+	Materials []struct {
+		Name                 string `json:"name"`
+		PBRMetallicRoughness struct {
+			BaseColorFactor          []float32    `json:"baseColorFactor"`
+			BaseColorTexture         *textureInfo `json:"baseColorTexture"`
+			MetallicFactor           *float32     `json:"metallicFactor"`
+			RoughnessFactor          *float32     `json:"roughnessFactor"`
+			MetallicRoughnessTexture *textureInfo `json:"metallicRoughnessTexture"`
+		} `json:"pbrMetallicRoughness"`
+		NormalTexture    *textureInfo `json:"normalTexture"`
+		OcclusionTexture *textureInfo `json:"occlusionTexture"`
+		EmissiveTexture  *textureInfo `json:"emissiveTexture"`
+		EmissiveFactor   []float32    `json:"emissiveFactor"`
+		AlphaMode        string       `json:"alphaMode"`
+		AlphaCutoff      *float32     `json:"alphaCutoff"`
+		DoubleSided      bool         `json:"doubleSided"`
+	} `json:"materials"`
+	Textures []struct {
+		Name    string `json:"name"`
+		Sampler *int   `json:"sampler"`
+		Source  *int   `json:"source"`
+	} `json:"textures"`
+	Samplers []struct {
+		MagFilter int `json:"magFilter"`
+		MinFilter int `json:"minFilter"`
+		WrapS     int `json:"wrapS"`
+		WrapT     int `json:"wrapT"`
+	} `json:"samplers"`
+	Images []struct {
+		Name       string `json:"name"`
+		URI        string `json:"uri"`
+		MimeType   string `json:"mimeType"`
+		BufferView *int   `json:"bufferView"`
+	} `json:"images"`
+	Skins []struct {
+		Name                string `json:"name"`
+		InverseBindMatrices *int   `json:"inverseBindMatrices"`
+		Skeleton            *int   `json:"skeleton"`
+		Joints              []int  `json:"joints"`
+	} `json:"skins"`
+	Animations []struct {
+		Name     string `json:"name"`
+		Channels []struct {
+			Sampler int `json:"sampler"`
+			Target  struct {
+				Node *int   `json:"node"`
+				Path string `json:"path"`
+			} `json:"target"`
+		} `json:"channels"`
+		Samplers []struct {
+			Input         int    `json:"input"`
+			Output        int    `json:"output"`
+			Interpolation string `json:"interpolation"`
+		} `json:"samplers"`
+	} `json:"animations"`
+	Accessors   []accessor   `json:"accessors"`
+	BufferViews []bufferView `json:"bufferViews"`
+	Buffers     []struct {
+		URI        string `json:"uri"`
+		ByteLength int    `json:"byteLength"`
+	} `json:"buffers"`
+	ExtensionsRequired []string `json:"extensionsRequired"`
+}
+
+//textureInfo is a texture reference in a material
+type textureInfo struct {
+	Index    int      `json:"index"`
+	TexCoord int      `json:"texCoord"`
+	Scale    *float32 `json:"scale"`
+	Strength *float32 `json:"strength"`
+}
+
+//=== Parsing
+
+//The magic numbers of a GLB file
+const (
+	glbMagic     = 0x46546C67 //glbMagic is "glTF"
+	glbChunkJSON = 0x4E4F534A //glbChunkJSON is "JSON"
+	glbChunkBIN  = 0x004E4942 //glbChunkBIN is "BIN"
+)
+
+//parser holds the state while a file is read
+type parser struct {
+	doc      *document
+	buffers  [][]byte
+	resolver Resolver
+}
+
+//Parse reads a .gltf or .glb file. External buffers and images are loaded with the resolver, which can be nil if the
+// file has everything embedded.
+func Parse(data []byte, resolver Resolver) (*Model, error) {
+	var bin []byte
+	if len(data) >= 4 && binary.LittleEndian.Uint32(data) == glbMagic {
+		var err error
+		if data, bin, err = readGLB(data); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := &document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.Asset.Version, "2.") || (doc.Asset.MinVersion != "" && !strings.HasPrefix(doc.Asset.MinVersion, "2.")) {
+		return nil, ErrUnsupportedVersion
+	}
+	if len(doc.ExtensionsRequired) > 0 {
+		return nil, fmt.Errorf("required extensions are not supported: %s", strings.Join(doc.ExtensionsRequired, ", "))
+	}
+
+	p := &parser{doc: doc, resolver: resolver}
+	if err := p.loadBuffers(bin); err != nil {
+		return nil, err
+	}
+
+	model := &Model{}
+	steps := []func(*Model) error{p.scenes, p.nodes, p.meshes, p.materials, p.textures, p.images, p.skins, p.animations}
+	for _, step := range steps {
+		if err := step(model); err != nil {
+			return nil, err
+		}
+	}
+	return model, nil
+}
+
+//readGLB splits a binary file into its JSON and binary chunks
+func readGLB(data []byte) ([]byte, []byte, error) {
+	if len(data) < 12 {
+		return nil, nil, ErrInvalidGLB
+	}
+	if binary.LittleEndian.Uint32(data[4:]) != 2 {
+		return nil, nil, ErrUnsupportedVersion
+	}
+
+	length := int(binary.LittleEndian.Uint32(data[8:]))
+	if length > len(data) {
+		return nil, nil, ErrInvalidGLB
+	}
+
+	var jsonChunk, binChunk []byte
+	for offset := 12; offset+8 <= length; {
+		size := int(binary.LittleEndian.Uint32(data[offset:]))
+		kind := binary.LittleEndian.Uint32(data[offset+4:])
+		offset += 8
+		if size < 0 || offset+size > length {
+			return nil, nil, ErrInvalidGLB
+		}
+
+		chunk := data[offset : offset+size]
+		switch {
+		case kind == glbChunkJSON && jsonChunk == nil:
+			jsonChunk = chunk
+		case kind == glbChunkBIN && binChunk == nil:
+			binChunk = chunk
+		}
+		offset += size
+	}
+
+	if jsonChunk == nil {
+		return nil, nil, ErrInvalidGLB
+	}
+	return jsonChunk, binChunk, nil
+}
+
+//loadBuffers loads the data of every buffer. The first buffer of a GLB file without a URI is its binary chunk.
+func (p *parser) loadBuffers(bin []byte) error {
+	p.buffers = make([][]byte, len(p.doc.Buffers))
+	for i, buffer := range p.doc.Buffers {
+		var data []byte
+		if buffer.URI == "" {
+			if i != 0 || bin == nil {
+				return fmt.Errorf("buffer %d has no data", i)
+			}
+			data = bin
+		} else {
+			var err error
+			if data, err = p.load(buffer.URI); err != nil {
+				return fmt.Errorf("buffer %d: %v", i, err)
+			}
+		}
+
+		if buffer.ByteLength < 0 {
+			return fmt.Errorf("buffer %d has a negative length", i)
+		}
+		if len(data) < buffer.ByteLength {
+			return fmt.Errorf("buffer %d should be %d bytes but is %d", i, buffer.ByteLength, len(data))
+		}
+		p.buffers[i] = data[:buffer.ByteLength]
+	}
+	return nil
+}
+
+//load gets the data of a URI, decoding data URIs and resolving everything else
+func (p *parser) load(uri string) ([]byte, error) {
+	if strings.HasPrefix(uri, "data:") {
+		comma := strings.IndexByte(uri, ',')
+		if comma < 0 || !strings.HasSuffix(uri[:comma], ";base64") {
+			return nil, fmt.Errorf("only base64 data URIs are supported")
+		}
+		return base64.StdEncoding.DecodeString(uri[comma+1:])
+	}
+
+	if p.resolver == nil {
+		return nil, ErrExternalResource
+	}
+	return p.resolver(uri)
+}
+
+//optional gets the value of an optional index, or -1 if it is not set
+func optional(index *int) int {
+	if index == nil {
+		return -1
+	}
+	return *index
+}
+
+//checkIndex makes sure an index refers to one of count objects
+func checkIndex(kind string, index, count int) error {
+	if index < 0 || index >= count {
+		return fmt.Errorf("%s %d does not exist", kind, index)
+	}
+	return nil
+}
+
+func (p *parser) scenes(model *Model) error {
+	for _, scene := range p.doc.Scenes {
+		for _, node := range scene.Nodes {
+			if err := checkIndex("node", node, len(p.doc.Nodes)); err != nil {
+				return err
+			}
+		}
+		model.Scenes = append(model.Scenes, &Scene{Name: scene.Name, Nodes: scene.Nodes})
+	}
+
+	model.Scene = optional(p.doc.Scene)
+	if model.Scene < 0 && len(model.Scenes) > 0 {
+		model.Scene = 0
+	}
+	if model.Scene >= 0 {
+		return checkIndex("scene", model.Scene, len(model.Scenes))
+	}
+	return nil
+}
+
+func (p *parser) nodes(model *Model) error {
+	model.Nodes = make([]*Node, len(p.doc.Nodes))
+	for i, source := range p.doc.Nodes {
+		node := &Node{
+			Name:      source.Name,
+			Parent:    -1,
+			Children:  source.Children,
+			Transform: noodle.NewTransformIdentity(),
+			Mesh:      optional(source.Mesh),
+			Skin:      optional(source.Skin),
+		}
+
+		if len(source.Matrix) == 16 {
+			node.Transform = noodle.NewTransformMatrix(matrixFromSlice(source.Matrix))
+		}
+		if t := source.Translation; len(t) == 3 {
+			node.Transform.Position = noodle.Vector3{X: t[0], Y: t[1], Z: t[2]}
+		}
+		if r := source.Rotation; len(r) == 4 {
+			node.Transform.Rotation = noodle.Quaternion{X: r[0], Y: r[1], Z: r[2], W: r[3]}
+		}
+		if s := source.Scale; len(s) == 3 {
+			node.Transform.Scale = noodle.Vector3{X: s[0], Y: s[1], Z: s[2]}
+		}
+
+		if node.Mesh >= 0 {
+			if err := checkIndex("mesh", node.Mesh, len(p.doc.Meshes)); err != nil {
+				return err
+			}
+		}
+		if node.Skin >= 0 {
+			if err := checkIndex("skin", node.Skin, len(p.doc.Skins)); err != nil {
+				return err
+			}
+		}
+		model.Nodes[i] = node
+	}
+
+	//Link up the parents, making sure the hierarchy is a tree
+	for i, node := range model.Nodes {
+		for _, child := range node.Children {
+			if err := checkIndex("node", child, len(model.Nodes)); err != nil {
+				return err
+			}
+			if child == i || model.Nodes[child].Parent >= 0 {
+				return fmt.Errorf("node %d has more than one parent", child)
+			}
+			model.Nodes[child].Parent = i
+		}
+	}
+	for i := range model.Nodes {
+		for parent, steps := model.Nodes[i].Parent, 0; parent >= 0; parent, steps = model.Nodes[parent].Parent, steps+1 {
+			if steps > len(model.Nodes) {
+				return fmt.Errorf("node %d is its own ancestor", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) meshes(model *Model) error {
+	for i, source := range p.doc.Meshes {
+		mesh := &Mesh{Name: source.Name}
+		for j, primitive := range source.Primitives {
+			result, err := p.primitive(primitive.Attributes, optional(primitive.Indices), primitive.Mode)
+			if err != nil {
+				return fmt.Errorf("mesh %d primitive %d: %v", i, j, err)
+			}
+
+			result.Material = optional(primitive.Material)
+			if result.Material >= 0 {
+				if err := checkIndex("material", result.Material, len(p.doc.Materials)); err != nil {
+					return err
+				}
+			}
+			mesh.Primitives = append(mesh.Primitives, result)
+		}
+		model.Meshes = append(model.Meshes, mesh)
+	}
+	return nil
+}
+
+//primitive reads the attributes of a primitive into a mesh
+func (p *parser) primitive(attributes map[string]int, indices int, mode *int) (*Primitive, error) {
+	mesh := noodle.NewMesh()
+	if mode != nil {
+		mesh.Mode = noodle.GLEnum(*mode)
+	}
+
+	//attribute reads an attribute that is required to have a number of components, if the primitive has it
+	attribute := func(name string, sizes ...int) ([]float32, int, error) {
+		index, ok := attributes[name]
+		if !ok {
+			return nil, 0, nil
+		}
+		values, components, err := p.readFloats(index)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %v", name, err)
+		}
+		for _, size := range sizes {
+			if components == size {
+				return values, components, nil
+			}
+		}
+		return nil, 0, fmt.Errorf("%s has the wrong type", name)
+	}
+
+	positions, _, err := attribute("POSITION", 3)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+2 < len(positions); i += 3 {
+		mesh.Positions = append(mesh.Positions, noodle.Vector3{X: positions[i], Y: positions[i+1], Z: positions[i+2]})
+	}
+
+	normals, _, err := attribute("NORMAL", 3)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+2 < len(normals); i += 3 {
+		mesh.Normals = append(mesh.Normals, noodle.Vector3{X: normals[i], Y: normals[i+1], Z: normals[i+2]})
+	}
+
+	uvs, _, err := attribute("TEXCOORD_0", 2)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(uvs); i += 2 {
+		mesh.UVs = append(mesh.UVs, noodle.Vector2{X: uvs[i], Y: uvs[i+1]})
+	}
+
+	colors, components, err := attribute("COLOR_0", 3, 4)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; components > 0 && i+components <= len(colors); i += components {
+		color := noodle.Vector4{X: colors[i], Y: colors[i+1], Z: colors[i+2], W: 1}
+		if components == 4 {
+			color.W = colors[i+3]
+		}
+		mesh.Colors = append(mesh.Colors, noodle.NewColorFromNormalized(color))
+	}
+
+	tangents, _, err := attribute("TANGENT", 4)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+3 < len(tangents); i += 4 {
+		mesh.Tangents = append(mesh.Tangents, noodle.Vector4{X: tangents[i], Y: tangents[i+1], Z: tangents[i+2], W: tangents[i+3]})
+	}
+
+	primitive := &Primitive{Mesh: mesh, Material: -1}
+	joints, _, err := attribute("JOINTS_0", 4)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+3 < len(joints); i += 4 {
+		primitive.Joints = append(primitive.Joints, [4]uint16{uint16(joints[i]), uint16(joints[i+1]), uint16(joints[i+2]), uint16(joints[i+3])})
+	}
+
+	weights, _, err := attribute("WEIGHTS_0", 4)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+3 < len(weights); i += 4 {
+		primitive.Weights = append(primitive.Weights, noodle.Vector4{X: weights[i], Y: weights[i+1], Z: weights[i+2], W: weights[i+3]})
+	}
+
+	if indices >= 0 {
+		if mesh.Indices, err = p.readIndices(indices); err != nil {
+			return nil, fmt.Errorf("indices: %v", err)
+		}
+		for _, index := range mesh.Indices {
+			if int(index) >= len(mesh.Positions) {
+				return nil, fmt.Errorf("index %d is out of range", index)
+			}
+		}
+	}
+
+	//Fill in what the file left out. Missing normals should be flat, which they are for vertices that are not shared.
+	if mesh.Mode == noodle.GlTriangles {
+		if len(mesh.Normals) == 0 {
+			if err := mesh.GenerateNormals(); err != nil {
+				return nil, err
+			}
+		}
+		if len(mesh.Tangents) == 0 && len(mesh.UVs) > 0 {
+			if err := mesh.GenerateTangents(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return primitive, nil
+}
+
+func (p *parser) materials(model *Model) error {
+	for _, source := range p.doc.Materials {
+		pbr := source.PBRMetallicRoughness
+		material := &Material{
+			Name:                     source.Name,
+			BaseColor:                noodle.Vector4{X: 1, Y: 1, Z: 1, W: 1},
+			BaseColorTexture:         p.textureInfo(pbr.BaseColorTexture, nil),
+			Metallic:                 1,
+			Roughness:                1,
+			MetallicRoughnessTexture: p.textureInfo(pbr.MetallicRoughnessTexture, nil),
+			NormalTexture:            p.textureInfo(source.NormalTexture, source.NormalTexture.scale()),
+			OcclusionTexture:         p.textureInfo(source.OcclusionTexture, source.OcclusionTexture.strength()),
+			EmissiveTexture:          p.textureInfo(source.EmissiveTexture, nil),
+			AlphaMode:                AlphaOpaque,
+			AlphaCutoff:              0.5,
+			DoubleSided:              source.DoubleSided,
+		}
+
+		if c := pbr.BaseColorFactor; len(c) == 4 {
+			material.BaseColor = noodle.Vector4{X: c[0], Y: c[1], Z: c[2], W: c[3]}
+		}
+		if pbr.MetallicFactor != nil {
+			material.Metallic = *pbr.MetallicFactor
+		}
+		if pbr.RoughnessFactor != nil {
+			material.Roughness = *pbr.RoughnessFactor
+		}
+		if e := source.EmissiveFactor; len(e) == 3 {
+			material.Emissive = noodle.Vector3{X: e[0], Y: e[1], Z: e[2]}
+		}
+		if source.AlphaMode != "" {
+			material.AlphaMode = AlphaMode(source.AlphaMode)
+		}
+		if source.AlphaCutoff != nil {
+			material.AlphaCutoff = *source.AlphaCutoff
+		}
+
+		for _, texture := range []*TextureInfo{material.BaseColorTexture, material.MetallicRoughnessTexture, material.NormalTexture, material.OcclusionTexture, material.EmissiveTexture} {
+			if texture != nil {
+				if err := checkIndex("texture", texture.Texture, len(p.doc.Textures)); err != nil {
+					return err
+				}
+			}
+		}
+		model.Materials = append(model.Materials, material)
+	}
+	return nil
+}
+
+//scale gets the scale of a normal texture
+func (t *textureInfo) scale() *float32 {
+	if t == nil {
+		return nil
+	}
+	return t.Scale
+}
+
+//strength gets the strength of an occlusion texture
+func (t *textureInfo) strength() *float32 {
+	if t == nil {
+		return nil
+	}
+	return t.Strength
+}
+
+//textureInfo converts a texture reference, which is nil if the material does not have the texture
+func (p *parser) textureInfo(source *textureInfo, scale *float32) *TextureInfo {
+	if source == nil {
+		return nil
+	}
+	info := &TextureInfo{Texture: source.Index, TexCoord: source.TexCoord, Scale: 1}
+	if scale != nil {
+		info.Scale = *scale
+	}
+	return info
+}
+
+func (p *parser) textures(model *Model) error {
+	for _, source := range p.doc.Textures {
+		texture := &Texture{
+			Name:      source.Name,
+			Image:     optional(source.Source),
+			MagFilter: noodle.TextureFilterLinear,
+			MinFilter: noodle.TextureFilterLinearMipmapLinear,
+			WrapS:     noodle.TextureWrapRepeat,
+			WrapT:     noodle.TextureWrapRepeat,
+		}
+		if texture.Image >= 0 {
+			if err := checkIndex("image", texture.Image, len(p.doc.Images)); err != nil {
+				return err
+			}
+		}
+
+		if source.Sampler != nil {
+			if err := checkIndex("sampler", *source.Sampler, len(p.doc.Samplers)); err != nil {
+				return err
+			}
+
+			//Samplers leave out the values that are up to the implementation
+			sampler := p.doc.Samplers[*source.Sampler]
+			if sampler.MagFilter != 0 {
+				texture.MagFilter = sampler.MagFilter
+			}
+			if sampler.MinFilter != 0 {
+				texture.MinFilter = sampler.MinFilter
+			}
+			if sampler.WrapS != 0 {
+				texture.WrapS = sampler.WrapS
+			}
+			if sampler.WrapT != 0 {
+				texture.WrapT = sampler.WrapT
+			}
+		}
+		model.Textures = append(model.Textures, texture)
+	}
+	return nil
+}
+
+func (p *parser) images(model *Model) error {
+	for i, source := range p.doc.Images {
+		image := &Image{Name: source.Name, URI: source.URI, MimeType: source.MimeType}
+
+		var err error
+		if source.BufferView != nil {
+			image.Data, err = p.bufferViewData(*source.BufferView)
+		} else if source.URI != "" {
+			image.Data, err = p.load(source.URI)
+			if image.MimeType == "" && strings.HasPrefix(source.URI, "data:") {
+				image.MimeType = strings.TrimSuffix(strings.TrimPrefix(source.URI[:strings.IndexByte(source.URI, ',')], "data:"), ";base64")
+			}
+		} else {
+			err = errors.New("no data")
+		}
+		if err != nil {
+			return fmt.Errorf("image %d: %v", i, err)
+		}
+		model.Images = append(model.Images, image)
+	}
+	return nil
+}
+
+func (p *parser) skins(model *Model) error {
+	for i, source := range p.doc.Skins {
+		skin := &Skin{Name: source.Name, Joints: source.Joints, Skeleton: optional(source.Skeleton)}
+		for _, joint := range skin.Joints {
+			if err := checkIndex("node", joint, len(p.doc.Nodes)); err != nil {
+				return err
+			}
+		}
+
+		skin.InverseBindMatrices = make([]noodle.Matrix, len(skin.Joints))
+		if source.InverseBindMatrices == nil {
+			for j := range skin.InverseBindMatrices {
+				skin.InverseBindMatrices[j] = noodle.NewMatrixIdentity()
+			}
+		} else {
+			values, components, err := p.readFloats(*source.InverseBindMatrices)
+			if err != nil {
+				return fmt.Errorf("skin %d: %v", i, err)
+			}
+			if components != 16 || len(values) < len(skin.Joints)*16 {
+				return fmt.Errorf("skin %d needs a matrix for each joint", i)
+			}
+			for j := range skin.InverseBindMatrices {
+				skin.InverseBindMatrices[j] = matrixFromSlice(values[j*16:])
+			}
+		}
+		model.Skins = append(model.Skins, skin)
+	}
+	return nil
+}
+
+//matrixFromSlice reads a column major matrix
+func matrixFromSlice(m []float32) noodle.Matrix {
+	return noodle.Matrix{
+		M0: m[0], M1: m[1], M2: m[2], M3: m[3],
+		M4: m[4], M5: m[5], M6: m[6], M7: m[7],
+		M8: m[8], M9: m[9], M10: m[10], M11: m[11],
+		M12: m[12], M13: m[13], M14: m[14], M15: m[15],
+	}
+}
+
+//bufferViewData gets the bytes of a buffer view
+func (p *parser) bufferViewData(index int) ([]byte, error) {
+	if err := checkIndex("buffer view", index, len(p.doc.BufferViews)); err != nil {
+		return nil, err
+	}
+	view := p.doc.BufferViews[index]
+	if err := checkIndex("buffer", view.Buffer, len(p.buffers)); err != nil {
+		return nil, err
+	}
+
+	buffer := p.buffers[view.Buffer]
+	if view.ByteOffset < 0 || view.ByteLength < 0 || view.ByteOffset+view.ByteLength > len(buffer) {
+		return nil, fmt.Errorf("buffer view %d is outside its buffer", index)
+	}
+	return buffer[view.ByteOffset : view.ByteOffset+view.ByteLength], nil
+}
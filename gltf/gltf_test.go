@@ -0,0 +1,215 @@
+package gltf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lachee/noodle"
+)
+
+//checkTriangle checks a model was read from the triangle in the testdata directory
+func checkTriangle(t *testing.T, model *Model) {
+	t.Helper()
+	if len(model.Scenes) != 1 || model.Scene != 0 || len(model.Nodes) != 1 || model.Nodes[0].Mesh != 0 {
+		t.Fatalf("unexpected hierarchy %+v", model)
+	}
+	if model.Nodes[0].Transform.Position != (noodle.Vector3{X: 1, Y: 2, Z: 3}) {
+		t.Errorf("unexpected transform %+v", model.Nodes[0].Transform)
+	}
+
+	mesh := model.Meshes[0].Primitives[0].Mesh
+	positions := []noodle.Vector3{{}, {X: 1}, {Y: 1}}
+	uvs := []noodle.Vector2{{}, {X: 1}, {Y: 1}}
+	if len(mesh.Positions) != 3 || len(mesh.UVs) != 3 || len(mesh.Indices) != 3 {
+		t.Fatalf("expected a triangle, got %d positions, %d UVs and %d indices", len(mesh.Positions), len(mesh.UVs), len(mesh.Indices))
+	}
+	for i := range positions {
+		if mesh.Positions[i] != positions[i] || mesh.UVs[i] != uvs[i] || mesh.Indices[i] != uint32(i) {
+			t.Errorf("vertex %d: unexpected %v %v %d", i, mesh.Positions[i], mesh.UVs[i], mesh.Indices[i])
+		}
+	}
+
+	//The file has no normals, so they are generated
+	if len(mesh.Normals) != 3 || mesh.Normals[0] != (noodle.Vector3{Z: 1}) {
+		t.Errorf("unexpected normals %v", mesh.Normals)
+	}
+}
+
+//readTriangle reads the text of the triangle, which uses the external triangle.bin
+func readTriangle(t *testing.T) string {
+	t.Helper()
+	data, err := ioutil.ReadFile("testdata/triangle.gltf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+//floatBuffer is the JSON of a buffer that holds the floats in a base64 data URI
+func floatBuffer(values ...float32) string {
+	var data bytes.Buffer
+	binary.Write(&data, binary.LittleEndian, values)
+	return fmt.Sprintf(`{"uri": "data:application/octet-stream;base64,%s", "byteLength": %d}`, base64.StdEncoding.EncodeToString(data.Bytes()), data.Len())
+}
+
+//parseJSON parses a model with the fields of the JSON, adding the asset version
+func parseJSON(t *testing.T, fields string) *Model {
+	t.Helper()
+	model, err := Parse([]byte(`{"asset": {"version": "2.0"}, `+fields+`}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+//testdataResolver loads external resources from the testdata directory
+func testdataResolver(uri string) ([]byte, error) {
+	return ioutil.ReadFile("testdata/" + uri)
+}
+
+func TestOpenLoadsExternalBuffers(t *testing.T) {
+	model, err := Open("testdata/triangle.gltf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTriangle(t, model)
+
+	if _, err := Parse([]byte(readTriangle(t)), nil); err == nil || !strings.Contains(err.Error(), ErrExternalResource.Error()) {
+		t.Errorf("expected ErrExternalResource without a resolver, got %v", err)
+	}
+}
+
+func TestParseGLB(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/triangle.glb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := Parse(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTriangle(t, model)
+
+	if _, err := Parse(data[:20], nil); err != ErrInvalidGLB {
+		t.Errorf("expected ErrInvalidGLB for a truncated file, got %v", err)
+	}
+}
+
+func TestLoadResolvesBuffers(t *testing.T) {
+	previous := noodle.Files
+	noodle.Files = os.DirFS("testdata")
+	defer func() { noodle.Files = previous }()
+
+	model, err := Load("/triangle.gltf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTriangle(t, model)
+}
+
+func TestParseRejectsInvalidSizes(t *testing.T) {
+	//Each case changes part of the triangle. None of them fit in the data, so they must fail rather than panic or
+	// allocate for elements that are not there.
+	cases := []struct{ name, old, new string }{
+		{"negative buffer", `"byteLength": 64`, `"byteLength": -1`},
+		{"short buffer", `"byteLength": 64`, `"byteLength": 65`},
+		{"view outside buffer", `"byteOffset": 60, "byteLength": 3`, `"byteOffset": 60, "byteLength": 8`},
+		{"huge count", `"count": 3, "type": "VEC3"`, `"count": 4611686018427387904, "type": "VEC3"`},
+		{"negative count", `"count": 3, "type": "SCALAR"`, `"count": -1, "type": "SCALAR"`},
+		{"huge stride", `"byteStride": 8`, `"byteStride": 4611686018427387904`},
+		{"huge offset", `{"bufferView": 0, "componentType"`, `{"bufferView": 0, "byteOffset": 9223372036854775807, "componentType"`},
+		{"huge count without a view", `{"bufferView": 0, "componentType": 5126, "count": 3`, `{"componentType": 5126, "count": 4611686018427387904`},
+		{"huge sparse count", `"count": 3, "type": "VEC3"`,
+			`"count": 3, "type": "VEC3", "sparse": {"count": 4611686018427387904, "indices": {"bufferView": 2, "componentType": 5121}, "values": {"bufferView": 0}}`},
+	}
+
+	triangle := readTriangle(t)
+	for _, c := range cases {
+		if !strings.Contains(triangle, c.old) {
+			t.Fatalf("%s: the triangle does not contain %s", c.name, c.old)
+		}
+		data := strings.Replace(triangle, c.old, c.new, 1)
+		if _, err := Parse([]byte(data), testdataResolver); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestParseLinksNodes(t *testing.T) {
+	model := parseJSON(t, `"nodes": [{"name": "root", "children": [1, 2]}, {"name": "left"}, {"name": "right", "children": [3]}, {"name": "leaf"}]`)
+	parents := []int{-1, 0, 0, 2}
+	for i, parent := range parents {
+		if model.Nodes[i].Parent != parent {
+			t.Errorf("node %d: expected the parent %d, got %d", i, parent, model.Nodes[i].Parent)
+		}
+	}
+	if model.Scene != -1 || len(model.Nodes[0].Children) != 2 {
+		t.Errorf("unexpected model %+v", model)
+	}
+}
+
+func TestParseRejectsInvalidHierarchies(t *testing.T) {
+	cases := []struct{ name, nodes, err string }{
+		{"two parents", `[{"children": [2]}, {"children": [2]}, {}]`, "node 2 has more than one parent"},
+		{"own child", `[{"children": [0]}]`, "node 0 has more than one parent"},
+		{"cycle", `[{"children": [1]}, {"children": [2]}, {"children": [0]}]`, "is its own ancestor"},
+		{"missing child", `[{"children": [1]}]`, "node 1 does not exist"},
+	}
+	for _, c := range cases {
+		_, err := Parse([]byte(`{"asset": {"version": "2.0"}, "nodes": `+c.nodes+`}`), nil)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestParseMaterials(t *testing.T) {
+	model := parseJSON(t, `
+		"materials": [
+			{
+				"name": "painted",
+				"pbrMetallicRoughness": {"baseColorFactor": [1, 0.5, 0, 0.75], "baseColorTexture": {"index": 0, "texCoord": 1}, "metallicFactor": 0, "roughnessFactor": 0.25},
+				"normalTexture": {"index": 0, "scale": 2},
+				"occlusionTexture": {"index": 0, "strength": 0.5},
+				"emissiveFactor": [0.1, 0.2, 0.3],
+				"alphaMode": "MASK", "alphaCutoff": 0.3, "doubleSided": true
+			},
+			{}
+		],
+		"textures": [{"source": 0}],
+		"images": [{"uri": "data:image/png;base64,AAEC"}]`)
+
+	painted, plain := model.Materials[0], model.Materials[1]
+	if painted.Name != "painted" || painted.BaseColor != (noodle.Vector4{X: 1, Y: 0.5, Z: 0, W: 0.75}) || painted.Metallic != 0 || painted.Roughness != 0.25 {
+		t.Errorf("unexpected factors %+v", painted)
+	}
+	if painted.BaseColorTexture == nil || *painted.BaseColorTexture != (TextureInfo{Texture: 0, TexCoord: 1, Scale: 1}) {
+		t.Errorf("unexpected base colour texture %+v", painted.BaseColorTexture)
+	}
+	if painted.NormalTexture.Scale != 2 || painted.OcclusionTexture.Scale != 0.5 || painted.EmissiveTexture != nil {
+		t.Errorf("unexpected textures %+v %+v", painted.NormalTexture, painted.OcclusionTexture)
+	}
+	if painted.Emissive != (noodle.Vector3{X: 0.1, Y: 0.2, Z: 0.3}) || painted.AlphaMode != AlphaMask || painted.AlphaCutoff != 0.3 || !painted.DoubleSided {
+		t.Errorf("unexpected surface %+v", painted)
+	}
+
+	//Everything left out of a material has the default from the specification
+	defaults := Material{BaseColor: noodle.Vector4{X: 1, Y: 1, Z: 1, W: 1}, Metallic: 1, Roughness: 1, AlphaMode: AlphaOpaque, AlphaCutoff: 0.5}
+	if *plain != defaults {
+		t.Errorf("expected the defaults %+v, got %+v", defaults, plain)
+	}
+	if image := model.Images[0]; image.MimeType != "image/png" || !bytes.Equal(image.Data, []byte{0, 1, 2}) {
+		t.Errorf("unexpected image %+v", image)
+	}
+
+	_, err := Parse([]byte(`{"asset": {"version": "2.0"}, "materials": [{"normalTexture": {"index": 1}}], "textures": [{}]}`), nil)
+	if err == nil || !strings.Contains(err.Error(), "texture 1 does not exist") {
+		t.Errorf("expected the missing texture to be reported, got %v", err)
+	}
+}
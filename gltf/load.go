@@ -0,0 +1,157 @@
+package gltf
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+
+	"github.com/lachee/noodle"
+
+	//Register the decoders for the image types glTF allows
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+//Open reads a model from a file on disk, loading external buffers and images from the same directory
+func Open(filename string) (*Model, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(filename)
+	return Parse(data, func(uri string) ([]byte, error) {
+		path, err := url.PathUnescape(uri)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	})
+}
+
+//Load reads a model from noodle.Files, and the external buffers and images it uses relative to it
+func Load(modelURL string) (*Model, error) {
+	data, err := noodle.ReadFile(modelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data, func(uri string) ([]byte, error) {
+		resourceURL, err := noodle.ResolvePath(modelURL, uri)
+		if err != nil {
+			return nil, err
+		}
+		return noodle.ReadFile(resourceURL)
+	})
+}
+
+//Upload sends the meshes of every primitive to the GPU
+func (m *Model) Upload() error {
+	for _, mesh := range m.Meshes {
+		for _, primitive := range mesh.Primitives {
+			if err := primitive.Mesh.Upload(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//Decode decodes the PNG or JPEG data of the image
+func (img *Image) Decode() (*image.RGBA, error) {
+	src, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba, nil
+	}
+
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	return rgba, nil
+}
+
+//LoadTexture decodes the image of a texture and uploads it. The texture only has a single level, so it is filtered
+// with its MagFilter and wrapped with its WrapS.
+func (m *Model) LoadTexture(index int) (*noodle.Texture, error) {
+	if err := checkIndex("texture", index, len(m.Textures)); err != nil {
+		return nil, err
+	}
+	texture := m.Textures[index]
+	if err := checkIndex("image", texture.Image, len(m.Images)); err != nil {
+		return nil, err
+	}
+
+	rgba, err := m.Images[texture.Image].Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := noodle.LoadImageRGBA(rgba)
+	if err != nil {
+		return nil, err
+	}
+
+	result := noodle.NewTexture(img)
+	result.SetFilter(texture.MagFilter)
+	result.SetWrap(texture.WrapS)
+	return result, nil
+}
+
+//WorldMatrix gets the matrix that moves the node into the space of the scene
+func (m *Model) WorldMatrix(node int) noodle.Matrix {
+	matrix := m.Nodes[node].Transform.ToMatrix()
+	for parent := m.Nodes[node].Parent; parent >= 0; parent = m.Nodes[parent].Parent {
+		matrix = m.Nodes[parent].Transform.ToMatrix().Multiply(matrix)
+	}
+	return matrix
+}
+
+//JointMatrices gets the matrix of each joint of the skin, which moves a vertex from its bind pose into the space of the scene.
+// Multiply them by the inverse world matrix of the node with the mesh to keep them relative to it.
+func (m *Model) JointMatrices(skin int) []noodle.Matrix {
+	s := m.Skins[skin]
+	matrices := make([]noodle.Matrix, len(s.Joints))
+	for i, joint := range s.Joints {
+		matrices[i] = m.WorldMatrix(joint).Multiply(s.InverseBindMatrices[i])
+	}
+	return matrices
+}
+
+//NewNode builds a noodle.Node hierarchy of a scene. The Data of each node is the *gltf.Node it was made from, and the
+// root node is named after the scene.
+func (m *Model) NewNode(scene int) *noodle.Node {
+	root := noodle.NewNode(m.Scenes[scene].Name)
+	for _, index := range m.Scenes[scene].Nodes {
+		root.AddChild(m.newNode(index))
+	}
+	return root
+}
+
+//newNode builds a noodle.Node and its children. The hierarchy was checked when it was parsed, so adding children cannot fail.
+func (m *Model) newNode(index int) *noodle.Node {
+	source := m.Nodes[index]
+	node := noodle.NewNode(source.Name)
+	node.Data = source
+	node.SetTransform(source.Transform)
+	for _, child := range source.Children {
+		node.AddChild(m.newNode(child))
+	}
+	return node
+}
+
+//Sync copies the transforms of the model's nodes onto a hierarchy made by NewNode, such as after an animation has been applied
+func Sync(root *noodle.Node) {
+	root.Walk(func(node *noodle.Node) bool {
+		if source, ok := node.Data.(*Node); ok {
+			node.SetTransform(source.Transform)
+		}
+		return true
+	})
+}
@@ -1,12 +1,5 @@
 package noodle
 
-import (
-	"errors"
-	"image"
-	"runtime"
-	"syscall/js"
-)
-
 //UVTile interface provides methods for sprites
 type UVTile interface {
 	//Texture returns the texture
@@ -100,71 +93,12 @@ func NewSliceSprite(source *Texture, rectangle Rectangle, border Vector2) *Slice
 
 //Image is a CPU image
 type Image struct {
-	data   js.Value
+	data   interface{} //data is a JS Image or ImageData in the browser, otherwise it is a Go *image.RGBA
 	format GLEnum
 	width  int
 	height int
 }
 
-//LoadImage loads a new image
-func LoadImage(url string) (*Image, error) {
-	ch := make(chan error, 1)
-	img := js.Global().Get("Image").New()
-
-	//Prepare the events
-	loadEvent := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		go func() { ch <- nil }()
-		return nil
-	})
-	defer loadEvent.Release()
-	img.Call("addEventListener", "load", loadEvent)
-
-	errorEvent := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		go func() { ch <- errors.New("Failed to load image") }()
-		return nil
-	})
-	defer errorEvent.Release()
-	img.Call("addEventListener", "error", errorEvent)
-
-	//Set the source
-	img.Set("src", url)
-
-	//Wait for the source to load
-	err := <-ch
-	if err != nil {
-		return nil, err
-	}
-
-	width := img.Get("width").Int()
-	height := img.Get("height").Int()
-
-	//Finish
-	return &Image{img, GlRGBA, width, height}, nil
-}
-
-//LoadImageRGBA loads a go RGBA image
-func LoadImageRGBA(rgba *image.RGBA) (*Image, error) {
-	//Get the pixels and convert it into a Uint8ClampedArray
-	s := rgba.Pix
-	a := js.Global().Get("Uint8Array").New(len(s))
-	js.CopyBytesToJS(a, sliceToByteSlice(s))
-	runtime.KeepAlive(s)
-	buf := a.Get("buffer")
-	ac := js.Global().Get("Uint8ClampedArray").New(buf, a.Get("byteOffset"), a.Get("byteLength"))
-
-	//Create the image data
-	bounds := rgba.Bounds()
-	imageData := js.Global().Get("ImageData").New(ac, bounds.Dx(), bounds.Dy())
-
-	//Return the final image
-	return &Image{imageData, GlRGBA, bounds.Dx(), bounds.Dy()}, nil
-}
-
-//Data gets the JS value
-func (i *Image) Data() js.Value {
-	return i.data
-}
-
 //Width gets the width in pixels
 func (i *Image) Width() int {
 	return i.width
@@ -226,6 +160,11 @@ type Texture struct {
 	width     int
 	height    int
 	noMipMaps bool
+
+	image   *Image        //image is the source of the pixels, kept so the texture can be restored
+	filter  TextureFilter //filter is the last filter set on the texture
+	wrap    TextureWrap   //wrap is the last wrap set on the texture
+	context *Context      //context is the context the texture was created in
 }
 
 //NewTexture a new Texture from the image
@@ -239,12 +178,33 @@ func NewTexture(image *Image) *Texture {
 		width:     image.Width(),
 		height:    image.Height(),
 		noMipMaps: true,
+		filter:    TextureFilterLinear,
+		wrap:      TextureWrapClampToEdge,
 	}
 
 	tex.SetImage(image)
+	tex.context = track(tex)
 	return tex
 }
 
+//Restore recreates the texture from its source image after the context has been lost
+func (tex *Texture) Restore() error {
+	tex.texture = GL.CreateTexture()
+	if tex.image != nil {
+		tex.SetImage(tex.image)
+	}
+	return nil
+}
+
+//Release deletes the texture from the GPU. It will no longer be restored if the context is lost.
+func (tex *Texture) Release() {
+	if tex.texture != nil {
+		tex.context.gl.DeleteTexture(tex.texture)
+		tex.texture = nil
+	}
+	tex.context.Untrack(tex)
+}
+
 //Width gets the width of the texture
 func (tex *Texture) Width() int { return tex.width }
 
@@ -272,30 +232,32 @@ func (tex *Texture) Data() WebGLTexture {
 	return tex.texture
 }
 
-//SetImage copies the data from the Image into the texture, setting initial filtering.
+//SetImage copies the data from the Image into the texture, applying the filtering and wrapping.
 func (tex *Texture) SetImage(image *Image) {
 
 	//Update the formatting
+	tex.image = image
 	tex.format = image.format
 
 	//Setup the texture
 	GL.BindTexture(tex.target, tex.texture)
-	GL.TexImage2D(tex.target, tex.level, tex.format, tex.format, GlUnsignedByte, image.Data())
+	GL.TexImage2D(tex.target, tex.level, tex.format, tex.format, GlUnsignedByte, image.data)
 
 	//Generate mips
 	//if !tex.noMipMaps && image.IsPowerOf2() {
 	//	GL.GenerateMipmap(tex.target)
 	//} else {
 	//Turn of mips, not square
-	GL.TexParameteri(tex.target, GlTextureWrapS, GlClampToEdge)
-	GL.TexParameteri(tex.target, GlTextureWrapT, GlClampToEdge)
-	GL.TexParameteri(tex.target, GlTextureMinFilter, GlLinear)
-	GL.TexParameteri(tex.target, GlTextureMagFilter, GlLinear)
+	GL.TexParameteri(tex.target, GlTextureWrapS, tex.wrap)
+	GL.TexParameteri(tex.target, GlTextureWrapT, tex.wrap)
+	GL.TexParameteri(tex.target, GlTextureMinFilter, tex.filter)
+	GL.TexParameteri(tex.target, GlTextureMagFilter, tex.filter)
 	//}
 }
 
 //SetFilter binds the texture and sets the filtering
 func (tex *Texture) SetFilter(filter TextureFilter) {
+	tex.filter = filter
 	GL.BindTexture(tex.target, tex.texture)
 	GL.TexParameteri(tex.target, GlTextureMinFilter, filter)
 	GL.TexParameteri(tex.target, GlTextureMagFilter, filter)
@@ -303,6 +265,7 @@ func (tex *Texture) SetFilter(filter TextureFilter) {
 
 //SetWrap binds the texture and sets how the texture will be wrapped
 func (tex *Texture) SetWrap(wrap TextureWrap) {
+	tex.wrap = wrap
 	GL.BindTexture(tex.target, tex.texture)
 	GL.TexParameteri(tex.target, GlTextureWrapS, wrap)
 	GL.TexParameteri(tex.target, GlTextureWrapT, wrap)
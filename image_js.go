@@ -0,0 +1,92 @@
+package noodle
+
+import (
+	"errors"
+	"image"
+	"runtime"
+	"syscall/js"
+)
+
+//LoadImage loads a new image from Files. Over HTTP the browser downloads and decodes the image itself, otherwise it
+// decodes the bytes that are read.
+func LoadImage(url string) (*Image, error) {
+	if files, ok := Files.(HTTPFS); ok {
+		return loadImageURL(files.url(url))
+	}
+
+	data, err := ReadFile(url)
+	if err != nil {
+		return nil, err
+	}
+	return LoadImageData(data)
+}
+
+//loadImageURL has the browser load an image from a URL
+func loadImageURL(url string) (*Image, error) {
+	ch := make(chan error, 1)
+	img := js.Global().Get("Image").New()
+
+	//Prepare the events
+	loadEvent := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		go func() { ch <- nil }()
+		return nil
+	})
+	defer loadEvent.Release()
+	img.Call("addEventListener", "load", loadEvent)
+
+	errorEvent := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		go func() { ch <- errors.New("failed to load image") }()
+		return nil
+	})
+	defer errorEvent.Release()
+	img.Call("addEventListener", "error", errorEvent)
+
+	//Set the source
+	img.Set("src", url)
+
+	//Wait for the source to load
+	err := <-ch
+	if err != nil {
+		return nil, err
+	}
+
+	width := img.Get("width").Int()
+	height := img.Get("height").Int()
+
+	//Finish
+	return &Image{img, GlRGBA, width, height}, nil
+}
+
+//Data gets the JS Image or ImageData the image was loaded into
+func (i *Image) Data() js.Value {
+	return i.data.(js.Value)
+}
+
+//LoadImageData loads an image from the bytes of an encoded file, such as a PNG. The browser decodes it from a blob URL.
+func LoadImageData(data []byte) (*Image, error) {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	blob := js.Global().Get("Blob").New([]interface{}{array})
+
+	url := js.Global().Get("URL").Call("createObjectURL", blob)
+	defer js.Global().Get("URL").Call("revokeObjectURL", url)
+	return loadImageURL(url.String())
+}
+
+//LoadImageRGBA loads a go RGBA image
+func LoadImageRGBA(rgba *image.RGBA) (*Image, error) {
+	//Get the pixels and convert it into a Uint8ClampedArray
+	s := rgba.Pix
+	a := js.Global().Get("Uint8Array").New(len(s))
+	js.CopyBytesToJS(a, sliceToByteSlice(s))
+	runtime.KeepAlive(s)
+	buf := a.Get("buffer")
+	ac := js.Global().Get("Uint8ClampedArray").New(buf, a.Get("byteOffset"), a.Get("byteLength"))
+
+	//Create the image data
+	bounds := rgba.Bounds()
+	imageData := js.Global().Get("ImageData").New(ac, bounds.Dx(), bounds.Dy())
+
+	//Return the final image
+	return &Image{imageData, GlRGBA, bounds.Dx(), bounds.Dy()}, nil
+}
@@ -0,0 +1,49 @@
+//go:build !js
+// +build !js
+
+package noodle
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+
+	//Register the decoders the browser would otherwise provide
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+//LoadImage loads a new image from Files. Outside of the browser the image is decoded in Go.
+func LoadImage(url string) (*Image, error) {
+	data, err := ReadFile(url)
+	if err != nil {
+		return nil, err
+	}
+	return LoadImageData(data)
+}
+
+//LoadImageData loads an image from the bytes of an encoded file, such as a PNG
+func LoadImageData(data []byte) (*Image, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	//Convert it into RGBA so its the same layout as the GPU expects
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	return LoadImageRGBA(rgba)
+}
+
+//LoadImageRGBA loads a go RGBA image
+func LoadImageRGBA(rgba *image.RGBA) (*Image, error) {
+	bounds := rgba.Bounds()
+	return &Image{rgba, GlRGBA, bounds.Dx(), bounds.Dy()}, nil
+}
+
+//RGBA gets the pixels of the image. Outside of the browser images are always decoded into RGBA.
+func (i *Image) RGBA() *image.RGBA {
+	return i.data.(*image.RGBA)
+}
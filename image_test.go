@@ -0,0 +1,32 @@
+//go:build !js
+// +build !js
+
+package noodle
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestLoadImageDataDecodesRGBA(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(2, 1, color.NRGBA{R: 255, A: 255})
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, src); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := LoadImageData(encoded.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width() != 3 || img.Height() != 2 {
+		t.Fatalf("expected 3x2, got %dx%d", img.Width(), img.Height())
+	}
+	if pixel := img.RGBA().RGBAAt(2, 1); pixel != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("expected a red pixel, got %v", pixel)
+	}
+}
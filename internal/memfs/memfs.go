@@ -0,0 +1,106 @@
+//Package memfs has the files and directories of the file systems that noodle holds in memory, such as HTTPFS, MemoryFS and packs.
+package memfs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//fileInfo describes a file that is held in memory
+type fileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i fileInfo) Name() string       { return path.Base(i.name) }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return i.dir }
+func (i fileInfo) Sys() interface{}   { return nil }
+func (i fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+//file is an open file that is held in memory
+type file struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+//dir is an open directory of files that are held in memory
+type dir struct {
+	info    fileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dir) Close() error               { return nil }
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+//ReadDir reads the next count entries, or all of the remaining entries if count is 0 or less
+func (d *dir) ReadDir(count int) ([]fs.DirEntry, error) {
+	remaining := len(d.entries) - d.offset
+	if count > 0 && remaining == 0 {
+		return nil, io.EOF
+	}
+	if count <= 0 || count > remaining {
+		count = remaining
+	}
+	entries := d.entries[d.offset : d.offset+count]
+	d.offset += count
+	return entries, nil
+}
+
+//OpenFile opens a file with the data. The data is read, not copied.
+func OpenFile(name string, data []byte) fs.File {
+	return &file{Reader: bytes.NewReader(data), info: fileInfo{name: name, size: int64(len(data))}}
+}
+
+//OpenDir opens a directory from the slash separated paths and sizes of every file in the file system. Directories are
+// implied by the paths of the files in them, so a directory that has nothing in it does not exist, unless it is the root.
+func OpenDir(name string, files map[string]int64) (fs.File, error) {
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+
+	//Find what is directly inside the directory
+	children := make(map[string]fileInfo)
+	for file, size := range files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		rest := file[len(prefix):]
+		if slash := strings.IndexByte(rest, '/'); slash >= 0 {
+			children[rest[:slash]] = fileInfo{name: rest[:slash], dir: true}
+		} else {
+			children[rest] = fileInfo{name: rest, size: size}
+		}
+	}
+	if len(children) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	d := &dir{info: fileInfo{name: name, dir: true}}
+	for _, child := range children {
+		d.entries = append(d.entries, fs.FileInfoToDirEntry(child))
+	}
+	sort.Slice(d.entries, func(i, j int) bool { return d.entries[i].Name() < d.entries[j].Name() })
+	return d, nil
+}
@@ -0,0 +1,280 @@
+package noodle
+
+import (
+	"errors"
+	"strings"
+)
+
+//ErrUniformType is returned when a material property is set to a value that does not match the type of the shader's uniform
+var ErrUniformType = errors.New("the value does not match the type of the uniform")
+
+//The names of the uniforms a Material and the MeshRenderer set. Shaders only need to declare the uniforms they use.
+const (
+	UniformModel      = "uModel"      //UniformModel is the mat4 that transforms the mesh into world space
+	UniformView       = "uView"       //UniformView is the mat4 that transforms world space into the space of the camera
+	UniformProjection = "uProjection" //UniformProjection is the mat4 projection of the camera
+	UniformColor      = "uColor"      //UniformColor is the vec4 colour of the material
+	UniformTexture    = "uTexture"    //UniformTexture is the sampler2D texture of the material
+	UniformTextured   = "uTextured"   //UniformTextured is a float that is 1 if the material has a texture, otherwise 0
+)
+
+//materialProperty is a value the material sets on a uniform
+type materialProperty struct {
+	value interface{}
+	dirty bool //dirty is true if the value has changed since it was last sent to GL
+}
+
+//Material is a shader and the values of its uniforms, such as colours, matrices and textures. Properties are set by the name
+// of the uniform, and only the ones that have changed are sent to GL when the material is used. Each sampler uniform gets its own
+// texture unit, in the order the shader reports them.
+//
+//Properties for uniforms the shader does not have are kept but not sent, so materials can be shared between shaders.
+// Setting a uniform of the shader directly with GL will not be noticed by the material.
+type Material struct {
+	Shader *Shader
+
+	properties map[string]*materialProperty
+	units      map[string]int //units are the texture units of the sampler uniforms
+	generation int            //generation is the generation of the shader the units were given out for
+}
+
+//NewMaterial creates a new white material that draws with the shader
+func NewMaterial(shader *Shader) *Material {
+	material := &Material{Shader: shader, properties: make(map[string]*materialProperty)}
+	material.SetColor(UniformColor, White)
+	return material
+}
+
+//NewUnlitMaterial creates a material with the built in unlit shader, which multiplies the vertex colours, the colour and the texture.
+// The texture may be nil. The shader is compiled once for each context and shared by all of its unlit materials, so releasing
+// it releases it for every one of them.
+func NewUnlitMaterial(texture *Texture) (*Material, error) {
+	shader, err := unlitShader()
+	if err != nil {
+		return nil, err
+	}
+
+	material := NewMaterial(shader)
+	material.SetTexture(UniformTexture, texture)
+	return material, nil
+}
+
+//unlitShader gets the unlit shader of the current context, compiling it if it has not been yet or has been released
+func unlitShader() (*Shader, error) {
+	if current.unlitShader == nil || current.unlitShader.program == nil {
+		shader, err := LoadShader(unlitVertCode, unlitFragCode)
+		if err != nil {
+			return nil, err
+		}
+		current.unlitShader = shader
+	}
+	return current.unlitShader, nil
+}
+
+//assignUnits gives each sampler uniform of the shader a texture unit. Every property needs to be sent again, as the
+// shader may have been restored.
+func (m *Material) assignUnits() {
+	m.units = make(map[string]int)
+	m.generation = m.Shader.generation
+	for _, uniform := range m.Shader.Uniforms() {
+		if uniform.Type == GlSampler2d {
+			m.units[strings.TrimSuffix(uniform.Name, "[0]")] = len(m.units)
+		}
+	}
+	m.markDirty()
+}
+
+//markDirty flags every property to be sent the next time the material is used
+func (m *Material) markDirty() {
+	for _, property := range m.properties {
+		property.dirty = true
+	}
+}
+
+//Get gets the value of a property, or nil if it has not been set
+func (m *Material) Get(name string) interface{} {
+	if property, ok := m.properties[name]; ok {
+		return property.value
+	}
+	return nil
+}
+
+//set stores the value of a property, marking it dirty if it has changed
+func (m *Material) set(name string, value interface{}) error {
+	if uniform, err := m.Shader.Uniform(name); err == nil && !uniformAccepts(uniform.Type, value) {
+		return ErrUniformType
+	}
+
+	property, ok := m.properties[name]
+	if !ok {
+		property = &materialProperty{}
+		m.properties[name] = property
+	} else if property.value == value {
+		return nil
+	}
+
+	property.value = value
+	property.dirty = true
+	return nil
+}
+
+//SetFloat sets a float uniform
+func (m *Material) SetFloat(name string, value float32) error { return m.set(name, value) }
+
+//SetInt sets an int or bool uniform
+func (m *Material) SetInt(name string, value int) error { return m.set(name, value) }
+
+//SetVector2 sets a vec2 uniform
+func (m *Material) SetVector2(name string, value Vector2) error { return m.set(name, value) }
+
+//SetVector3 sets a vec3 uniform
+func (m *Material) SetVector3(name string, value Vector3) error { return m.set(name, value) }
+
+//SetVector4 sets a vec4 uniform
+func (m *Material) SetVector4(name string, value Vector4) error { return m.set(name, value) }
+
+//SetColor sets a vec4 uniform to the colour from 0 to 1, or a vec3 uniform to just its red, green and blue
+func (m *Material) SetColor(name string, value Color) error { return m.set(name, value) }
+
+//SetMatrix sets a mat4 uniform
+func (m *Material) SetMatrix(name string, value Matrix) error { return m.set(name, value) }
+
+//SetTexture sets a sampler2D uniform. The texture may be nil. Setting UniformTexture also sets UniformTextured to
+// whether there is a texture.
+func (m *Material) SetTexture(name string, value *Texture) error {
+	if err := m.set(name, value); err != nil {
+		return err
+	}
+
+	if name == UniformTexture {
+		textured := float32(0)
+		if value != nil {
+			textured = 1
+		}
+		return m.SetFloat(UniformTextured, textured)
+	}
+	return nil
+}
+
+//uniformAccepts checks if a property value can be sent to a uniform of the type
+func uniformAccepts(valueType GLEnum, value interface{}) bool {
+	switch value.(type) {
+	case float32:
+		return valueType == GlFloat
+	case int:
+		return valueType == GlInt || valueType == GlBool
+	case Vector2:
+		return valueType == GlFloatVec2
+	case Vector3:
+		return valueType == GlFloatVec3
+	case Vector4:
+		return valueType == GlFloatVec4
+	case Color:
+		return valueType == GlFloatVec4 || valueType == GlFloatVec3
+	case Matrix:
+		return valueType == GlFloatMat4
+	case *Texture:
+		return valueType == GlSampler2d
+	default:
+		return false
+	}
+}
+
+//Use tells GL to use the material's shader and sends the properties that have changed. Textures are bound every time,
+// as other renderers share the texture units.
+func (m *Material) Use() {
+	m.Shader.Use()
+	if m.units == nil || m.generation != m.Shader.generation {
+		m.assignUnits()
+	}
+
+	//Another material may have changed the uniforms since this one last set them
+	if m.Shader.material != m {
+		m.markDirty()
+		m.Shader.material = m
+	}
+
+	otherUnits := false
+	for name, property := range m.properties {
+		uniform, err := m.Shader.Uniform(name)
+		if err != nil {
+			property.dirty = false
+			continue
+		}
+
+		unit := m.units[name]
+		if texture, ok := property.value.(*Texture); ok {
+			GL.ActiveTexture(GlTexture0 + unit)
+			if texture != nil {
+				texture.Bind()
+			} else {
+				GL.UnbindTexture(GlTexture2D)
+			}
+			otherUnits = otherUnits || unit > 0
+		}
+
+		if property.dirty {
+			sendUniform(uniform, unit, property.value)
+			property.dirty = false
+		}
+	}
+
+	//Leave the first unit active, which is what the other renderers expect
+	if otherUnits {
+		GL.ActiveTexture(GlTexture0)
+	}
+}
+
+//sendUniform sends a property value to its uniform
+func sendUniform(uniform ShaderUniform, unit int, value interface{}) {
+	switch v := value.(type) {
+	case float32:
+		GL.Uniform1f(uniform.Location, v)
+	case int:
+		GL.Uniform1i(uniform.Location, v)
+	case Vector2:
+		GL.Uniform2v(uniform.Location, v)
+	case Vector3:
+		GL.Uniform3v(uniform.Location, v)
+	case Vector4:
+		GL.Uniform4v(uniform.Location, v)
+	case Color:
+		color := v.Normalize()
+		if uniform.Type == GlFloatVec3 {
+			GL.Uniform3f(uniform.Location, color.X, color.Y, color.Z)
+		} else {
+			GL.Uniform4v(uniform.Location, color)
+		}
+	case Matrix:
+		GL.UniformMatrix4fv(uniform.Location, v)
+	case *Texture:
+		GL.Uniform1i(uniform.Location, unit)
+	}
+}
+
+var unlitVertCode = `
+attribute vec3 position;
+attribute vec2 texcoord;
+attribute vec4 color;
+uniform mat4 uModel;
+uniform mat4 uView;
+uniform mat4 uProjection;
+varying vec2 vTexCoord;
+varying vec4 vColor;
+void main() {
+	vTexCoord = texcoord;
+	vColor = color;
+	gl_Position = uProjection * uView * uModel * vec4(position, 1.0);
+}`
+
+var unlitFragCode = `
+precision mediump float;
+uniform vec4 uColor;
+uniform sampler2D uTexture;
+uniform float uTextured;
+varying vec2 vTexCoord;
+varying vec4 vColor;
+void main() {
+	vec4 texel = mix(vec4(1.0), texture2D(uTexture, vTexCoord), uTextured);
+	gl_FragColor = vColor * uColor * texel;
+}`
@@ -0,0 +1,199 @@
+package noodle
+
+import "testing"
+
+//materialFragCode has a uniform of each type the material sets, and two samplers
+var materialFragCode = `
+precision mediump float;
+uniform vec4 uColor;
+uniform vec3 uTint;
+uniform float uShininess;
+uniform int uMode;
+uniform vec2 uOffset;
+uniform mat4 uBones[4];
+uniform sampler2D uTexture, uNormalMap;
+uniform float uTextured;
+void main() { gl_FragColor = uColor; }`
+
+//newTestMaterial compiles the material shader and makes a material with it
+func newTestMaterial(t *testing.T) *Material {
+	t.Helper()
+	shader, err := LoadShader(unlitVertCode, materialFragCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewMaterial(shader)
+}
+
+func TestMaterialSetsTypedProperties(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	material := newTestMaterial(t)
+
+	//Each value must match the type of its uniform
+	mismatched := map[string]error{
+		"vec3 as colour":   material.SetVector3(UniformColor, Vector3{}),
+		"float as int":     material.SetFloat("uMode", 1),
+		"int as float":     material.SetInt("uShininess", 1),
+		"matrix as vector": material.SetVector2("uBones", Vector2{}),
+		"texture as float": material.SetTexture("uShininess", nil),
+	}
+	for name, err := range mismatched {
+		if err != ErrUniformType {
+			t.Errorf("%s: expected ErrUniformType, got %v", name, err)
+		}
+	}
+	if material.Get("uShininess") != nil {
+		t.Error("a rejected value was kept")
+	}
+
+	//Values for uniforms the shader does not have are kept, but not sent
+	properties := []error{
+		material.SetColor("uTint", Red),
+		material.SetFloat("uShininess", 32),
+		material.SetInt("uMode", 2),
+		material.SetVector2("uOffset", Vector2{1, 2}),
+		material.SetMatrix("uBones", NewMatrixTranslate(Vector3{1, 2, 3})),
+		material.SetFloat("uMissing", 1),
+	}
+	for i, err := range properties {
+		if err != nil {
+			t.Errorf("property %d: %v", i, err)
+		}
+	}
+	if material.Get("uMissing") != float32(1) {
+		t.Error("the property of a missing uniform was not kept")
+	}
+
+	material.Use()
+	program, gl := material.Shader.program, platform.GL()
+	red := Red.Normalize()
+	values := map[string]interface{}{
+		"uColor":     White.Normalize(),
+		"uTint":      Vector3{red.X, red.Y, red.Z},
+		"uShininess": float32(32),
+		"uMode":      2,
+		"uOffset":    Vector2{1, 2},
+		"uBones[0]":  NewMatrixTranslate(Vector3{1, 2, 3}),
+	}
+	for name, expected := range values {
+		if value := gl.UniformValue(program, name); value != expected {
+			t.Errorf("%s: expected %v, got %v", name, expected, value)
+		}
+	}
+}
+
+func TestMaterialOnlySendsChanges(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	gl := platform.GL()
+	material := newTestMaterial(t)
+	material.SetColor("uTint", Red)
+	material.Use()
+
+	//Nothing has changed, so nothing is sent
+	gl.ResetCalls()
+	material.Use()
+	if calls := len(callsNamed(gl, "uniform4fv")) + len(callsNamed(gl, "uniform3f")); calls != 0 {
+		t.Errorf("expected nothing to be sent, got %d uniforms", calls)
+	}
+
+	//Setting the same value again does not make it dirty
+	gl.ResetCalls()
+	material.SetColor(UniformColor, Blue)
+	material.SetColor("uTint", Red)
+	material.Use()
+	if len(callsNamed(gl, "uniform4fv")) != 1 || len(callsNamed(gl, "uniform3f")) != 0 {
+		t.Errorf("expected only the colour to be sent, got %v", gl.Calls())
+	}
+
+	//Another material using the shader changes its uniforms, so everything is sent again
+	NewMaterial(material.Shader).Use()
+	gl.ResetCalls()
+	material.Use()
+	if len(callsNamed(gl, "uniform3f")) != 1 {
+		t.Error("the tint was not sent again after another material used the shader")
+	}
+
+	//As is everything after the shader has been restored
+	if err := material.Shader.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	gl.ResetCalls()
+	material.Use()
+	if len(callsNamed(gl, "uniform3f")) != 1 {
+		t.Error("the tint was not sent again after the shader was restored")
+	}
+}
+
+func TestMaterialTextureUnits(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	gl := platform.GL()
+	material := newTestMaterial(t)
+	texture, normals := newTestTexture(t, 2, 2), newTestTexture(t, 2, 2)
+
+	if err := material.SetTexture("uNormalMap", normals); err != nil {
+		t.Fatal(err)
+	}
+	if err := material.SetTexture(UniformTexture, texture); err != nil || material.Get(UniformTextured) != float32(1) {
+		t.Fatalf("expected setting the texture to set uTextured, got %v", material.Get(UniformTextured))
+	}
+	material.Use()
+
+	//Each sampler gets its own unit, in the order the shader has them, and the first unit is left active
+	if unit := gl.UniformValue(material.Shader.program, UniformTexture); unit != 0 {
+		t.Errorf("expected uTexture on unit 0, got %v", unit)
+	}
+	if unit := gl.UniformValue(material.Shader.program, "uNormalMap"); unit != 1 {
+		t.Errorf("expected uNormalMap on unit 1, got %v", unit)
+	}
+	active := callsNamed(gl, "activeTexture")
+	if len(active) != 3 || active[len(active)-1].Args[0] != GlTexture0 {
+		t.Errorf("expected both units to be bound and the first to be left active, got %v", active)
+	}
+
+	//Textures are bound every time, as other renderers share the units
+	gl.ResetCalls()
+	material.Use()
+	if binds := callsNamed(gl, "bindTexture"); len(binds) != 2 {
+		t.Errorf("expected both textures to be bound again, got %d", len(binds))
+	}
+
+	material.SetTexture(UniformTexture, nil)
+	if material.Get(UniformTextured) != float32(0) {
+		t.Error("expected removing the texture to clear uTextured")
+	}
+}
+
+func TestUnlitMaterialsShareShader(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	first, err := NewUnlitMaterial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewUnlitMaterial(newTestTexture(t, 2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Shader != second.Shader || len(callsNamed(platform.GL(), "linkProgram")) != 1 {
+		t.Fatal("expected the unlit materials to share one shader")
+	}
+
+	//Once released, the next unlit material compiles it again
+	first.Shader.Release()
+	third, err := NewUnlitMaterial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.Shader == first.Shader || third.Shader.program == nil {
+		t.Error("expected a new shader after the shared one was released")
+	}
+
+	//Another context has its own shader
+	startHeadless(t, &headlessApp{})
+	fourth, err := NewUnlitMaterial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fourth.Shader == third.Shader {
+		t.Error("expected each context to have its own shader")
+	}
+}
@@ -35,6 +35,14 @@ func Clamp32(v float32, min float32, max float32) float32 {
 	return v
 }
 
+//Abs32 returns the absolute value of v
+func Abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 // Float32frombits returns the floating point number corresponding
 // to the IEEE 754 binary representation b.
 func Float32frombits(b uint32) float32 { return *(*float32)(unsafe.Pointer(&b)) }
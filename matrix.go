@@ -62,7 +62,8 @@ type Matrix struct {
 
 func newMatrixFromPointer(ptr unsafe.Pointer) Matrix { return *(*Matrix)(ptr) }
 
-//NewMatrixQuaternion creates a new rotation matrix from a quaternion
+//NewMatrixQuaternion creates a new matrix from a quaternion. The matrix is the transpose of Quaternion.ToMatrix, so it
+// rotates the opposite way to the quaternion. Use Quaternion.ToMatrix for a matrix that performs the rotation.
 // https://www.euclideanspace.com/maths/geometry/rotations/conversions/quaternionToMatrix/index.htm
 func NewMatrixQuaternion(q Quaternion) Matrix {
 	sqw := q.W * q.W
@@ -156,9 +157,9 @@ func NewMatrixRotate(axis Vector3, radians float32) Matrix {
 	}
 }
 
-//NewMatrixTransform creates a new matrix based off a transform
+//NewMatrixTransform creates a new matrix based off a transform. It rotates the same way as the transform's quaternion.
 func NewMatrixTransform(transform Transform) Matrix {
-	return NewMatrixTranslate(transform.Position).Multiply(NewMatrixQuaternion(transform.Rotation)).Multiply(NewMatrixScale(transform.Scale))
+	return NewMatrixTranslate(transform.Position).Multiply(transform.Rotation.Normalize().ToMatrix()).Multiply(NewMatrixScale(transform.Scale))
 }
 
 //Trace of the matrix (sum of values along diagonal)
@@ -0,0 +1,64 @@
+package noodle
+
+import (
+	"math"
+	"testing"
+)
+
+//matrixNear checks if every element of the matrices is within the tolerance
+func matrixNear(a, b Matrix, tolerance float32) bool {
+	left, right := a.Decompose(), b.Decompose()
+	for i := range left {
+		if Abs32(left[i]-right[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+//vectorNear checks if the vectors are within the tolerance
+func vectorNear(a, b Vector3, tolerance float32) bool {
+	return Abs32(a.X-b.X) <= tolerance && Abs32(a.Y-b.Y) <= tolerance && Abs32(a.Z-b.Z) <= tolerance
+}
+
+func TestNewMatrixQuaternionIsTransposed(t *testing.T) {
+	q := NewQuaternionEuler(Vector3{0.5, -1.2, 0.25})
+	if matrix := NewMatrixQuaternion(q); !matrixNear(matrix, q.ToMatrix().Transpose(), 1e-5) {
+		t.Errorf("expected the transpose of %v, got %v", q.ToMatrix(), matrix)
+	}
+}
+
+//Transform.ToMatrix used to be built from NewMatrixQuaternion, which rotated the opposite way to the quaternion
+func TestTransformMatrixMatchesQuaternion(t *testing.T) {
+	rotations := []Quaternion{
+		{Z: float32(math.Sin(math.Pi / 4)), W: float32(math.Cos(math.Pi / 4))},
+		NewQuaternionAxisAngle(Vector3{1, 2, 3}, 0.4),
+		NewQuaternionEuler(Vector3{0.5, -1.2, 0.25}),
+	}
+
+	for _, q := range rotations {
+		matrix := NewTransform(Vector3{}, q, NewVector3One()).ToMatrix()
+		v := Vector3{1, -2, 0.5}
+		if rotated := v.Transform(matrix); !vectorNear(rotated, q.Rotate(v), 1e-4) {
+			t.Errorf("%v: the matrix rotated to %v, the quaternion to %v", q, rotated, q.Rotate(v))
+		}
+	}
+
+	//A quarter turn around Z takes X to Y, even when the quaternion has not been normalized
+	quarter := NewTransform(Vector3{}, Quaternion{Z: 2, W: 2}, NewVector3One()).ToMatrix()
+	if rotated := (Vector3{1, 0, 0}).Transform(quarter); !vectorNear(rotated, Vector3{0, 1, 0}, 1e-5) {
+		t.Errorf("expected a quarter turn to rotate X to Y, got %v", rotated)
+	}
+}
+
+func TestNewTransformMatrixRoundTrips(t *testing.T) {
+	transform := NewTransform(Vector3{4, -5, 6}, NewQuaternionEuler(Vector3{0.2, 0.4, 0.6}), Vector3{2, 3, 4})
+	decomposed := NewTransformMatrix(transform.ToMatrix())
+
+	if !vectorNear(decomposed.Position, transform.Position, 1e-4) || !vectorNear(decomposed.Scale, transform.Scale, 1e-4) {
+		t.Errorf("expected %v, got %v", transform, decomposed)
+	}
+	if !matrixNear(decomposed.ToMatrix(), transform.ToMatrix(), 1e-4) {
+		t.Errorf("the rotation did not round trip: expected %v, got %v", transform.Rotation, decomposed.Rotation)
+	}
+}
@@ -0,0 +1,362 @@
+package noodle
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	//ErrMeshLayout is returned when a mesh attribute does not have a value for every position
+	ErrMeshLayout = errors.New("mesh attributes must have one value for every position")
+	//ErrMeshIndex is returned when one of a mesh's indices is not the index of a position
+	ErrMeshIndex = errors.New("mesh index is out of range")
+)
+
+//The names of the attributes a Mesh binds its data to. Shaders only need to declare the attributes they use.
+const (
+	AttributePosition = "position" //AttributePosition is a vec3 position
+	AttributeNormal   = "normal"   //AttributeNormal is a vec3 normal
+	AttributeTexCoord = "texcoord" //AttributeTexCoord is a vec2 texture coordinate
+	AttributeColor    = "color"    //AttributeColor is a vec4 colour from 0 to 1
+	AttributeTangent  = "tangent"  //AttributeTangent is a vec4 tangent, with the handedness of the bitangent in w
+)
+
+//meshAttribute describes how one of the mesh's buffers is laid out
+type meshAttribute struct {
+	name       string
+	size       int
+	valueType  GLEnum
+	normalized bool
+	fallback   Vector4 //fallback is the value used when the mesh does not have the attribute
+}
+
+//meshLayout is the standard vertex layout. Each attribute has its own buffer.
+var meshLayout = [...]meshAttribute{
+	{AttributePosition, 3, GlFloat, false, Vector4{0, 0, 0, 1}},
+	{AttributeNormal, 3, GlFloat, false, Vector4{0, 0, 1, 0}},
+	{AttributeTexCoord, 2, GlFloat, false, Vector4{0, 0, 0, 0}},
+	{AttributeColor, 4, GlUnsignedByte, true, Vector4{1, 1, 1, 1}},
+	{AttributeTangent, 4, GlFloat, false, Vector4{1, 0, 0, 1}},
+}
+
+//Mesh is 3D geometry stored on the GPU. Fill in the attributes, then call Upload. Every attribute but the positions is optional.
+type Mesh struct {
+	Positions []Vector3
+	Normals   []Vector3
+	UVs       []Vector2
+	Colors    []Color
+	Tangents  []Vector4
+
+	//Indices are the vertices of each primitive. If empty, the vertices are drawn in order.
+	// They are uploaded as 16 bit if they fit, otherwise 32 bit which WebGL1 needs OES_element_index_uint for.
+	Indices []uint32
+
+	//Mode is the primitive that is drawn, such as GlTriangles or GlLines
+	Mode GLEnum
+
+	buffers     [len(meshLayout)]WebGLBuffer
+	indexBuffer WebGLBuffer
+	indexType   GLEnum
+	indexCount  int
+	vertexCount int
+	context     *Context //context is the context the mesh was uploaded to, or nil if it has not been
+}
+
+//NewMesh creates a new empty mesh of triangles
+func NewMesh() *Mesh {
+	return &Mesh{Mode: GlTriangles}
+}
+
+//VertexCount gets the number of vertices that were last uploaded
+func (m *Mesh) VertexCount() int { return m.vertexCount }
+
+//IndexCount gets the number of indices that were last uploaded
+func (m *Mesh) IndexCount() int { return m.indexCount }
+
+//Bounds gets the smallest box that contains all the positions
+func (m *Mesh) Bounds() (Vector3, Vector3) {
+	if len(m.Positions) == 0 {
+		return Vector3{}, Vector3{}
+	}
+
+	min, max := m.Positions[0], m.Positions[0]
+	for _, position := range m.Positions[1:] {
+		min = min.Min(position)
+		max = max.Max(position)
+	}
+	return min, max
+}
+
+//Upload sends the attributes and indices to the GPU. Call it again after changing them. It returns ErrMeshLayout or
+// ErrMeshIndex if the attributes or indices do not match the positions, leaving the buffers as they were.
+func (m *Mesh) Upload() error {
+	if err := m.upload(); err != nil {
+		return err
+	}
+
+	if m.context == nil {
+		m.context = track(m)
+	}
+	return nil
+}
+
+//Restore uploads the mesh again after the context has been lost
+func (m *Mesh) Restore() error {
+	m.buffers = [len(meshLayout)]WebGLBuffer{}
+	m.indexBuffer = nil
+	return m.upload()
+}
+
+//Release deletes the buffers of the mesh. The attributes are kept, so it can be uploaded again.
+func (m *Mesh) Release() {
+	if m.context == nil {
+		return
+	}
+
+	for i, buffer := range m.buffers {
+		if buffer != nil {
+			m.context.gl.DeleteBuffer(buffer)
+			m.buffers[i] = nil
+		}
+	}
+
+	if m.indexBuffer != nil {
+		m.context.gl.DeleteBuffer(m.indexBuffer)
+		m.indexBuffer = nil
+	}
+
+	m.indexCount = 0
+	m.vertexCount = 0
+	m.context.Untrack(m)
+	m.context = nil
+}
+
+//attributeData gets the data for the attribute in the layout and how many vertices it has
+func (m *Mesh) attributeData(attribute int) (interface{}, int) {
+	switch meshLayout[attribute].name {
+	case AttributePosition:
+		return m.Positions, len(m.Positions)
+	case AttributeNormal:
+		return m.Normals, len(m.Normals)
+	case AttributeTexCoord:
+		return m.UVs, len(m.UVs)
+	case AttributeTangent:
+		return m.Tangents, len(m.Tangents)
+	case AttributeColor:
+		colors := make([]uint8, 0, len(m.Colors)*4)
+		for _, color := range m.Colors {
+			colors = append(colors, color.R, color.G, color.B, color.A)
+		}
+		return colors, len(m.Colors)
+	default:
+		return nil, 0
+	}
+}
+
+//upload creates the buffers that are missing and fills them in
+func (m *Mesh) upload() error {
+	vertexCount := len(m.Positions)
+
+	//Check the layout before anything is sent, so a bad mesh leaves the old buffers alone
+	for i := range meshLayout {
+		if _, count := m.attributeData(i); count != 0 && count != vertexCount {
+			return ErrMeshLayout
+		}
+	}
+
+	maxIndex, err := m.checkIndices()
+	if err != nil {
+		return err
+	}
+	if maxIndex > 0xFFFF && !GL.Supports(FeatureElementIndexUint) {
+		return ErrNotSupported
+	}
+
+	//Upload each of the attributes into their own buffers
+	for i := range meshLayout {
+		data, count := m.attributeData(i)
+		if count == 0 {
+			if m.buffers[i] != nil {
+				GL.DeleteBuffer(m.buffers[i])
+				m.buffers[i] = nil
+			}
+			continue
+		}
+
+		if m.buffers[i] == nil {
+			m.buffers[i] = GL.CreateBuffer()
+		}
+		GL.BindBuffer(GlArrayBuffer, m.buffers[i])
+		GL.BufferData(GlArrayBuffer, data, GlStaticDraw)
+	}
+	m.vertexCount = vertexCount
+
+	//Upload the indices, using the smallest type they fit in
+	m.indexCount = len(m.Indices)
+	if m.indexCount == 0 {
+		if m.indexBuffer != nil {
+			GL.DeleteBuffer(m.indexBuffer)
+			m.indexBuffer = nil
+		}
+		return nil
+	}
+
+	if m.indexBuffer == nil {
+		m.indexBuffer = GL.CreateBuffer()
+	}
+	GL.BindBuffer(GlElementArrayBuffer, m.indexBuffer)
+	if maxIndex > 0xFFFF {
+		m.indexType = GlUnsignedInt
+		GL.BufferData(GlElementArrayBuffer, m.Indices, GlStaticDraw)
+	} else {
+		indices := make([]uint16, len(m.Indices))
+		for i, index := range m.Indices {
+			indices[i] = uint16(index)
+		}
+		m.indexType = GlUnsignedShort
+		GL.BufferData(GlElementArrayBuffer, indices, GlStaticDraw)
+	}
+	return nil
+}
+
+//Draw binds the attributes to the shader and draws the mesh. The shader must already be in use.
+// Attributes the shader uses but the mesh does not have are given a constant value, such as white for colours.
+func (m *Mesh) Draw(shader *Shader) {
+	enabled := make([]WebGLAttributeLocation, 0, len(meshLayout))
+	for i, attribute := range meshLayout {
+		location := shader.GetAttribLocation(attribute.name)
+		if location < 0 {
+			continue
+		}
+
+		if m.buffers[i] == nil {
+			fallback := attribute.fallback
+			GL.DisableVertexAttribArray(location)
+			GL.VertexAttrib4f(location, fallback.X, fallback.Y, fallback.Z, fallback.W)
+			continue
+		}
+
+		GL.BindBuffer(GlArrayBuffer, m.buffers[i])
+		GL.VertexAttribPointer(location, attribute.size, attribute.valueType, attribute.normalized, 0, 0)
+		GL.EnableVertexAttribArray(location)
+		enabled = append(enabled, location)
+	}
+
+	if m.indexBuffer != nil {
+		GL.BindBuffer(GlElementArrayBuffer, m.indexBuffer)
+		GL.DrawElements(m.Mode, m.indexCount, m.indexType, 0)
+	} else {
+		GL.DrawArrays(m.Mode, 0, m.vertexCount)
+	}
+
+	//Turn the arrays off again so they do not leak into other renderers
+	for _, location := range enabled {
+		GL.DisableVertexAttribArray(location)
+	}
+}
+
+//GenerateNormals calculates smooth Normals from the triangles. Each vertex gets the average of the faces it is part of,
+// weighted by their area, so vertices that are not shared give flat shading. It returns ErrMeshIndex if an index is
+// not the index of a position.
+func (m *Mesh) GenerateNormals() error {
+	if _, err := m.checkIndices(); err != nil {
+		return err
+	}
+
+	normals := make([]Vector3, len(m.Positions))
+	m.eachTriangle(func(a, b, c uint32) {
+		face := m.Positions[b].Subtract(m.Positions[a]).CrossProduct(m.Positions[c].Subtract(m.Positions[a]))
+		for _, index := range [3]uint32{a, b, c} {
+			normals[index] = normals[index].Add(face)
+		}
+	})
+
+	for i, normal := range normals {
+		if normal.SqrLength() == 0 {
+			normal = Vector3{0, 1, 0}
+		}
+		normals[i] = normal.Normalize()
+	}
+	m.Normals = normals
+	return nil
+}
+
+//GenerateTangents calculates the Tangents from the positions, normals and UVs. The tangent points along increasing U,
+// and w is the sign that turns the cross product of the normal and tangent into the bitangent, which points along increasing V.
+// Nothing is generated if the mesh does not have a normal and UV for every position. It returns ErrMeshIndex if an index
+// is not the index of a position.
+func (m *Mesh) GenerateTangents() error {
+	if len(m.Normals) != len(m.Positions) || len(m.UVs) != len(m.Positions) {
+		return nil
+	}
+	if _, err := m.checkIndices(); err != nil {
+		return err
+	}
+
+	tangents := make([]Vector3, len(m.Positions))
+	bitangents := make([]Vector3, len(m.Positions))
+	m.eachTriangle(func(a, b, c uint32) {
+		edge1 := m.Positions[b].Subtract(m.Positions[a])
+		edge2 := m.Positions[c].Subtract(m.Positions[a])
+		uv1 := m.UVs[b].Subtract(m.UVs[a])
+		uv2 := m.UVs[c].Subtract(m.UVs[a])
+
+		det := uv1.X*uv2.Y - uv2.X*uv1.Y
+		if det == 0 {
+			return
+		}
+
+		r := 1 / det
+		tangent := edge1.Scale(uv2.Y * r).Subtract(edge2.Scale(uv1.Y * r))
+		bitangent := edge2.Scale(uv1.X * r).Subtract(edge1.Scale(uv2.X * r))
+		for _, index := range [3]uint32{a, b, c} {
+			tangents[index] = tangents[index].Add(tangent)
+			bitangents[index] = bitangents[index].Add(bitangent)
+		}
+	})
+
+	//Make the tangents perpendicular to the normals
+	m.Tangents = make([]Vector4, len(m.Positions))
+	for i, normal := range m.Normals {
+		tangent := tangents[i].Subtract(normal.Scale(normal.DotProduct(tangents[i])))
+		if tangent.SqrLength() == 0 {
+			tangent = normal.Perpendicular()
+		}
+		tangent = tangent.Normalize()
+
+		w := float32(1)
+		if normal.CrossProduct(tangent).DotProduct(bitangents[i]) < 0 {
+			w = -1
+		}
+		m.Tangents[i] = Vector4{tangent.X, tangent.Y, tangent.Z, w}
+	}
+	return nil
+}
+
+//checkIndices makes sure every index is the index of a position, returning the largest
+func (m *Mesh) checkIndices() (uint32, error) {
+	var maxIndex uint32
+	for _, index := range m.Indices {
+		if int(index) >= len(m.Positions) {
+			return 0, fmt.Errorf("%w: %d is not below the %d positions", ErrMeshIndex, index, len(m.Positions))
+		}
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+	return maxIndex, nil
+}
+
+//eachTriangle calls the function with the vertices of every triangle, with or without indices. The indices must have been checked.
+func (m *Mesh) eachTriangle(fn func(a, b, c uint32)) {
+	if len(m.Indices) > 0 {
+		for i := 0; i+2 < len(m.Indices); i += 3 {
+			fn(m.Indices[i], m.Indices[i+1], m.Indices[i+2])
+		}
+		return
+	}
+
+	for i := uint32(0); int(i)+2 < len(m.Positions); i += 3 {
+		fn(i, i+1, i+2)
+	}
+}
@@ -0,0 +1,66 @@
+package noodle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeshLargeIndicesNeedExtension(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	platform.GL().SetVersion(1)
+	platform.GL().SetSupported(FeatureElementIndexUint, false)
+
+	mesh := NewMesh()
+	mesh.Positions = make([]Vector3, 70000)
+	mesh.Indices = []uint32{0, 1, 69999}
+	if err := mesh.Upload(); err != ErrNotSupported {
+		t.Fatalf("expected ErrNotSupported without OES_element_index_uint, got %v", err)
+	}
+
+	platform.GL().SetSupported(FeatureElementIndexUint, true)
+	if err := mesh.Upload(); err != nil {
+		t.Fatal(err)
+	}
+	shader, err := LoadShader(unlitVertCode, unlitFragCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mesh.Draw(shader)
+	if draws := platform.GL().DrawCalls(); len(draws) != 1 || draws[0].ValueType != GlUnsignedInt {
+		t.Fatalf("expected a draw with 32 bit indices, got %+v", draws)
+	}
+}
+
+func TestMeshRejectsIndicesOutOfRange(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	mesh := NewMesh()
+	mesh.Positions = []Vector3{{}, {X: 1}, {Y: 1}}
+	mesh.UVs = []Vector2{{}, {X: 1}, {Y: 1}}
+	mesh.Indices = []uint32{0, 1, 2}
+	if err := mesh.Upload(); err != nil {
+		t.Fatal(err)
+	}
+
+	//A bad index is reported instead of panicking, and the uploaded buffers are left alone
+	mesh.Indices = []uint32{0, 1, 3}
+	platform.GL().ResetCalls()
+	if err := mesh.Upload(); !errors.Is(err, ErrMeshIndex) {
+		t.Errorf("Upload: expected ErrMeshIndex, got %v", err)
+	}
+	if calls := callsNamed(platform.GL(), "bufferData"); len(calls) != 0 || mesh.IndexCount() != 3 {
+		t.Errorf("expected the buffers to be left alone, got %d uploads", len(calls))
+	}
+	if err := mesh.GenerateNormals(); !errors.Is(err, ErrMeshIndex) || mesh.Normals != nil {
+		t.Errorf("GenerateNormals: expected ErrMeshIndex, got %v", err)
+	}
+
+	mesh.Normals = []Vector3{{Z: 1}, {Z: 1}, {Z: 1}}
+	if err := mesh.GenerateTangents(); !errors.Is(err, ErrMeshIndex) || mesh.Tangents != nil {
+		t.Errorf("GenerateTangents: expected ErrMeshIndex, got %v", err)
+	}
+
+	mesh.Indices = []uint32{0, 1, 2}
+	if err := mesh.GenerateTangents(); err != nil || len(mesh.Tangents) != 3 {
+		t.Errorf("expected the tangents to be generated, got %v", err)
+	}
+}
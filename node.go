@@ -0,0 +1,242 @@
+package noodle
+
+import (
+	"errors"
+	"math"
+)
+
+//ErrNodeCycle is returned when a node would become its own ancestor
+var ErrNodeCycle = errors.New("a node cannot be parented to itself or its descendants")
+
+//Node is part of a scene graph. Each node has a Transform relative to its parent, and the world matrix is cached until
+// the node or one of its ancestors moves.
+type Node struct {
+	Name string      //Name identifies the node
+	Data interface{} //Data is whatever the node represents, such as a sprite or mesh
+
+	local    Transform
+	parent   *Node
+	children []*Node
+
+	world Matrix
+	dirty bool
+}
+
+//NewNode creates a new node with no parent at the origin
+func NewNode(name string) *Node {
+	return &Node{
+		Name:  name,
+		local: NewTransformIdentity(),
+		dirty: true,
+	}
+}
+
+//=== Hierarchy
+
+//Parent gets the parent of the node, or nil if it is a root
+func (n *Node) Parent() *Node { return n.parent }
+
+//Children gets the children of the node. The slice must not be modified.
+func (n *Node) Children() []*Node { return n.children }
+
+//Root gets the top most ancestor of the node
+func (n *Node) Root() *Node {
+	root := n
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+//IsAncestorOf checks if the node is a parent, grandparent or further up the tree of the other node
+func (n *Node) IsAncestorOf(other *Node) bool {
+	for p := other.parent; p != nil; p = p.parent {
+		if p == n {
+			return true
+		}
+	}
+	return false
+}
+
+//AddChild parents the child to this node, keeping its local transform
+func (n *Node) AddChild(child *Node) error {
+	return child.SetParent(n, false)
+}
+
+//RemoveChild removes the child from this node, making it a root. Its local transform is kept.
+func (n *Node) RemoveChild(child *Node) {
+	if child.parent == n {
+		child.SetParent(nil, false)
+	}
+}
+
+//SetParent moves the node to a new parent. A nil parent makes the node a root.
+// If keepWorld is true, the local transform is changed so the node stays where it is in the world.
+func (n *Node) SetParent(parent *Node, keepWorld bool) error {
+	if parent == n || (parent != nil && n.IsAncestorOf(parent)) {
+		return ErrNodeCycle
+	}
+	if parent == n.parent {
+		return nil
+	}
+
+	//Work out the new local transform before anything moves
+	if keepWorld {
+		world := n.WorldMatrix()
+		if parent != nil {
+			world = parent.WorldMatrix().Invert().Multiply(world)
+		}
+		n.local = NewTransformMatrix(world)
+	}
+
+	//Detach from the old parent
+	if n.parent != nil {
+		siblings := n.parent.children
+		for i, sibling := range siblings {
+			if sibling == n {
+				n.parent.children = append(siblings[:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+
+	n.markDirty()
+	return nil
+}
+
+//Walk visits the node and all of its descendants, parents before their children. If the function returns false,
+// the children of that node are skipped.
+func (n *Node) Walk(fn func(node *Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.children {
+		child.Walk(fn)
+	}
+}
+
+//Find finds the first node with the name in this node or its descendants
+func (n *Node) Find(name string) *Node {
+	var found *Node
+	n.Walk(func(node *Node) bool {
+		if found == nil && node.Name == name {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
+
+//=== Local Transform
+
+//Transform gets the transform of the node relative to its parent
+func (n *Node) Transform() Transform { return n.local }
+
+//SetTransform sets the transform of the node relative to its parent
+func (n *Node) SetTransform(transform Transform) {
+	n.local = transform
+	n.markDirty()
+}
+
+//Position gets the position of the node relative to its parent
+func (n *Node) Position() Vector3 { return n.local.Position }
+
+//SetPosition sets the position of the node relative to its parent
+func (n *Node) SetPosition(position Vector3) {
+	n.local.Position = position
+	n.markDirty()
+}
+
+//Rotation gets the rotation of the node relative to its parent
+func (n *Node) Rotation() Quaternion { return n.local.Rotation }
+
+//SetRotation sets the rotation of the node relative to its parent
+func (n *Node) SetRotation(rotation Quaternion) {
+	n.local.Rotation = rotation
+	n.markDirty()
+}
+
+//Scale gets the scale of the node relative to its parent
+func (n *Node) Scale() Vector3 { return n.local.Scale }
+
+//SetScale sets the scale of the node relative to its parent
+func (n *Node) SetScale(scale Vector3) {
+	n.local.Scale = scale
+	n.markDirty()
+}
+
+//SetTransform2D sets the transform of the node relative to its parent from a 2D transform. The rotation is in degrees around the Z axis.
+func (n *Node) SetTransform2D(transform Transform2D) {
+	half := float64(transform.Rotation*Deg2Rad) / 2
+	n.SetTransform(Transform{
+		Position: Vector3{transform.Position.X, transform.Position.Y, 0},
+		Rotation: Quaternion{X: 0, Y: 0, Z: float32(math.Sin(half)), W: float32(math.Cos(half))},
+		Scale:    Vector3{transform.Scale.X, transform.Scale.Y, 1},
+	})
+}
+
+//=== World Transform
+
+//markDirty flags the node and all of its descendants as needing their world matrix recalculated
+func (n *Node) markDirty() {
+	//If this node is already dirty, then so are all of its children
+	if n.dirty {
+		return
+	}
+
+	n.dirty = true
+	for _, child := range n.children {
+		child.markDirty()
+	}
+}
+
+//WorldMatrix gets the matrix that transforms from the node's local space into world space. It is only recalculated when something has moved.
+func (n *Node) WorldMatrix() Matrix {
+	if n.dirty {
+		n.world = n.local.ToMatrix()
+		if n.parent != nil {
+			n.world = n.parent.WorldMatrix().Multiply(n.world)
+		}
+		n.dirty = false
+	}
+	return n.world
+}
+
+//WorldTransform gets the transform of the node in world space
+func (n *Node) WorldTransform() Transform {
+	return NewTransformMatrix(n.WorldMatrix())
+}
+
+//WorldPosition gets the position of the node in world space
+func (n *Node) WorldPosition() Vector3 {
+	world := n.WorldMatrix()
+	return Vector3{world.M12, world.M13, world.M14}
+}
+
+//SetWorldPosition moves the node so it is at the position in world space
+func (n *Node) SetWorldPosition(position Vector3) {
+	if n.parent != nil {
+		inverse := n.parent.WorldMatrix().Invert()
+		position = position.Transform(inverse)
+	}
+	n.SetPosition(position)
+}
+
+//WorldTransform2D gets the transform of the node in world space as a 2D transform, so it can be given to the SpriteRenderer.
+// The rotation is in degrees around the Z axis.
+func (n *Node) WorldTransform2D() Transform2D {
+	world := n.WorldMatrix()
+	return Transform2D{
+		Position: Vector2{world.M12, world.M13},
+		Rotation: float32(math.Atan2(float64(world.M1), float64(world.M0))) * Rad2Deg,
+		Scale: Vector2{
+			Vector3{world.M0, world.M1, world.M2}.Length(),
+			Vector3{world.M4, world.M5, world.M6}.Length(),
+		},
+	}
+}
@@ -0,0 +1,166 @@
+package noodle
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+//quarterTurn is a quarter turn anticlockwise around Z, which takes X to Y
+var quarterTurn = Quaternion{Z: float32(math.Sin(math.Pi / 4)), W: float32(math.Cos(math.Pi / 4))}
+
+//nodeNames gets the names of the nodes, joined with spaces
+func nodeNames(nodes []*Node) string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func TestNodeAddChildKeepsLocalTransform(t *testing.T) {
+	ship := NewNode("ship")
+	ship.SetPosition(Vector3{10, 0, 0})
+	ship.SetRotation(quarterTurn)
+	turret := NewNode("turret")
+	turret.SetPosition(Vector3{1, 0, 0})
+
+	if err := ship.AddChild(turret); err != nil {
+		t.Fatal(err)
+	}
+	if turret.Parent() != ship || nodeNames(ship.Children()) != "turret" || turret.Root() != ship {
+		t.Fatal("the turret was not added to the ship")
+	}
+
+	//The turret is 1 along the ship's X, which has been turned to point along Y
+	if turret.Position() != (Vector3{1, 0, 0}) {
+		t.Errorf("expected the local position to be kept, got %v", turret.Position())
+	}
+	if world := turret.WorldPosition(); !vectorNear(world, Vector3{10, 1, 0}, 1e-5) {
+		t.Errorf("expected the turret to be at (10, 1, 0), got %v", world)
+	}
+}
+
+func TestNodeSetParentKeepsWorldTransform(t *testing.T) {
+	ship := NewNode("ship")
+	ship.SetTransform(NewTransform(Vector3{5, -2, 3}, quarterTurn, Vector3{2, 2, 2}))
+	turret := NewNode("turret")
+	turret.SetTransform(NewTransform(Vector3{1, 2, 3}, NewQuaternionEuler(Vector3{0.2, 0.4, 0.6}), NewVector3One()))
+	world := turret.WorldMatrix()
+
+	if err := turret.SetParent(ship, true); err != nil {
+		t.Fatal(err)
+	}
+	if !matrixNear(turret.WorldMatrix(), world, 1e-4) {
+		t.Errorf("the turret moved when it was parented: expected %v, got %v", world, turret.WorldMatrix())
+	}
+	if vectorNear(turret.Position(), Vector3{1, 2, 3}, 1e-4) {
+		t.Error("the local position was not changed to make up for the parent")
+	}
+
+	//Moving back to the root keeps it in place too
+	if err := turret.SetParent(nil, true); err != nil {
+		t.Fatal(err)
+	}
+	if !matrixNear(turret.WorldMatrix(), world, 1e-4) || !vectorNear(turret.Position(), Vector3{1, 2, 3}, 1e-4) {
+		t.Errorf("the turret moved when it was unparented, it is at %v", turret.Position())
+	}
+	if turret.Parent() != nil || len(ship.Children()) != 0 {
+		t.Error("the turret is still a child of the ship")
+	}
+}
+
+func TestNodeWorldFollowsAncestors(t *testing.T) {
+	fleet := NewNode("fleet")
+	ship := NewNode("ship")
+	turret := NewNode("turret")
+	fleet.AddChild(ship)
+	ship.AddChild(turret)
+	ship.SetPosition(Vector3{0, 1, 0})
+	turret.SetPosition(Vector3{1, 0, 0})
+
+	if world := turret.WorldPosition(); !vectorNear(world, Vector3{1, 1, 0}, 1e-5) {
+		t.Fatalf("expected (1, 1, 0), got %v", world)
+	}
+
+	//The turret's matrix has been cached, so moving an ancestor must mark it dirty
+	fleet.SetPosition(Vector3{100, 0, 0})
+	if world := turret.WorldPosition(); !vectorNear(world, Vector3{101, 1, 0}, 1e-5) {
+		t.Errorf("expected the turret to follow the fleet to (101, 1, 0), got %v", world)
+	}
+	ship.SetRotation(quarterTurn)
+	if world := turret.WorldPosition(); !vectorNear(world, Vector3{100, 2, 0}, 1e-5) {
+		t.Errorf("expected the turret to turn with the ship to (100, 2, 0), got %v", world)
+	}
+
+	//Moving a child leaves its parent alone
+	turret.SetPosition(Vector3{})
+	if world := ship.WorldPosition(); !vectorNear(world, Vector3{100, 1, 0}, 1e-5) {
+		t.Errorf("expected the ship to stay at (100, 1, 0), got %v", world)
+	}
+	turret.SetWorldPosition(Vector3{0, 0, 0})
+	if world := turret.WorldPosition(); !vectorNear(world, Vector3{}, 1e-4) {
+		t.Errorf("expected the turret to be moved to the origin, got %v", world)
+	}
+}
+
+func TestNodeSetParentRejectsCycles(t *testing.T) {
+	ship := NewNode("ship")
+	turret := NewNode("turret")
+	barrel := NewNode("barrel")
+	ship.AddChild(turret)
+	turret.AddChild(barrel)
+
+	if err := ship.SetParent(ship, false); !errors.Is(err, ErrNodeCycle) {
+		t.Errorf("parenting to itself: expected ErrNodeCycle, got %v", err)
+	}
+	if err := ship.SetParent(barrel, true); !errors.Is(err, ErrNodeCycle) {
+		t.Errorf("parenting to a descendant: expected ErrNodeCycle, got %v", err)
+	}
+	if err := turret.AddChild(ship); !errors.Is(err, ErrNodeCycle) {
+		t.Errorf("adding an ancestor: expected ErrNodeCycle, got %v", err)
+	}
+
+	if ship.Parent() != nil || turret.Parent() != ship || barrel.Parent() != turret || nodeNames(barrel.Children()) != "" {
+		t.Error("a rejected parent changed the hierarchy")
+	}
+}
+
+func TestNodeRemoveWalkAndFind(t *testing.T) {
+	root := NewNode("root")
+	left := NewNode("left")
+	right := NewNode("right")
+	leaf := NewNode("leaf")
+	other := NewNode("leaf")
+	root.AddChild(left)
+	root.AddChild(right)
+	left.AddChild(leaf)
+	right.AddChild(other)
+
+	//Parents are visited before their children, and returning false skips the children
+	var visited []*Node
+	root.Walk(func(node *Node) bool {
+		visited = append(visited, node)
+		return node != right
+	})
+	if names := nodeNames(visited); names != "root left leaf right" {
+		t.Errorf("expected root left leaf right, got %s", names)
+	}
+	if root.Find("leaf") != leaf || right.Find("leaf") != other || root.Find("missing") != nil {
+		t.Error("Find did not find the first node with the name")
+	}
+
+	//Removing a node that is not a child does nothing
+	root.RemoveChild(leaf)
+	if leaf.Parent() != left {
+		t.Error("removed the child of another node")
+	}
+	root.RemoveChild(left)
+	if left.Parent() != nil || nodeNames(root.Children()) != "right" || root.Find("leaf") != other {
+		t.Errorf("expected only the right to be left, got %s", nodeNames(root.Children()))
+	}
+	if left.Find("leaf") != leaf {
+		t.Error("the removed node lost its children")
+	}
+}
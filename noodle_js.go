@@ -0,0 +1,17 @@
+package noodle
+
+import (
+	"syscall/js"
+)
+
+//Run setups the WebGL context on the "gocanvas" canvas and runs the application. It is blocking and returns an exit code if Exit() is ever called.
+func Run(application Application) int {
+	return RunPlatform(application, NewDOMPlatform("gocanvas"))
+}
+
+//AddEventListener adds a new event listener to the document. It will return a JS function that needs to be Released() when its no longer required.
+func AddEventListener(event string, fn func(this js.Value, args []js.Value) interface{}) js.Func {
+	jsfunc := js.FuncOf(fn)
+	js.Global().Get("document").Call("addEventListener", event, jsfunc)
+	return jsfunc
+}
@@ -0,0 +1,45 @@
+package obj
+
+import "github.com/lachee/noodle"
+
+//Load reads the OBJ file and the MTL files it uses from noodle.Files. The texture paths of the materials are resolved against the
+// URL of their MTL file, so they can be passed straight to noodle.LoadImage.
+func Load(objURL string) (*Model, error) {
+	data, err := noodle.ReadFile(objURL)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, library := range model.MaterialLibraries {
+		mtlURL, err := noodle.ResolvePath(objURL, library)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := noodle.ReadFile(mtlURL)
+		if err != nil {
+			return nil, err
+		}
+
+		materials, err := ParseMTL(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, material := range materials {
+			for _, texture := range material.Textures() {
+				if *texture, err = noodle.ResolvePath(mtlURL, *texture); err != nil {
+					return nil, err
+				}
+			}
+		}
+		model.AddMaterials(materials)
+	}
+
+	return model, nil
+}
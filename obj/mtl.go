@@ -0,0 +1,132 @@
+package obj
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lachee/noodle"
+)
+
+//Material is a material from an MTL file. Texture paths are as written in the file, relative to the MTL file, and are empty if not set.
+type Material struct {
+	Name      string
+	Ambient   noodle.Color //Ambient is the Ka colour
+	Diffuse   noodle.Color //Diffuse is the Kd colour
+	Specular  noodle.Color //Specular is the Ks colour
+	Emissive  noodle.Color //Emissive is the Ke colour
+	Shininess float32      //Shininess is the Ns specular exponent
+	Opacity   float32      //Opacity is 1 for solid, from d or 1 - Tr
+
+	AmbientTexture  string //AmbientTexture is map_Ka
+	DiffuseTexture  string //DiffuseTexture is map_Kd
+	SpecularTexture string //SpecularTexture is map_Ks
+	EmissiveTexture string //EmissiveTexture is map_Ke
+	NormalTexture   string //NormalTexture is map_Bump, bump or norm
+	OpacityTexture  string //OpacityTexture is map_d
+}
+
+//newMaterial creates a white, solid material
+func newMaterial(name string) *Material {
+	return &Material{
+		Name:     name,
+		Ambient:  noodle.Black,
+		Diffuse:  noodle.White,
+		Specular: noodle.Black,
+		Emissive: noodle.Black,
+		Opacity:  1,
+	}
+}
+
+//ParseMTL reads an MTL file, returning the materials by name
+func ParseMTL(data []byte) (map[string]*Material, error) {
+	materials := make(map[string]*Material)
+	var material *Material
+
+	err := eachLine(data, func(line int, fields []string) error {
+		if fields[0] == "newmtl" {
+			material = newMaterial(strings.Join(fields[1:], " "))
+			materials[material.Name] = material
+			return nil
+		}
+
+		if material == nil {
+			return fmt.Errorf("%s is not part of a material", fields[0])
+		}
+
+		var err error
+		switch fields[0] {
+		case "Ka":
+			material.Ambient, err = parseColor(fields[1:])
+		case "Kd":
+			material.Diffuse, err = parseColor(fields[1:])
+		case "Ks":
+			material.Specular, err = parseColor(fields[1:])
+		case "Ke":
+			material.Emissive, err = parseColor(fields[1:])
+		case "Ns":
+			var values []float32
+			if values, err = parseFloats(fields[1:], 1); err == nil {
+				material.Shininess = values[0]
+			}
+		case "d":
+			var values []float32
+			if values, err = parseFloats(fields[1:], 1); err == nil {
+				material.Opacity = values[0]
+			}
+		case "Tr":
+			var values []float32
+			if values, err = parseFloats(fields[1:], 1); err == nil {
+				material.Opacity = 1 - values[0]
+			}
+		case "map_Ka":
+			material.AmbientTexture, err = texturePath(fields)
+		case "map_Kd":
+			material.DiffuseTexture, err = texturePath(fields)
+		case "map_Ks":
+			material.SpecularTexture, err = texturePath(fields)
+		case "map_Ke":
+			material.EmissiveTexture, err = texturePath(fields)
+		case "map_Bump", "map_bump", "bump", "norm":
+			material.NormalTexture, err = texturePath(fields)
+		case "map_d":
+			material.OpacityTexture, err = texturePath(fields)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return materials, nil
+}
+
+//Textures gets a pointer to every texture path of the material that is set, so they can be resolved
+func (m *Material) Textures() []*string {
+	all := []*string{&m.AmbientTexture, &m.DiffuseTexture, &m.SpecularTexture, &m.EmissiveTexture, &m.NormalTexture, &m.OpacityTexture}
+	textures := make([]*string, 0, len(all))
+	for _, texture := range all {
+		if *texture != "" {
+			textures = append(textures, texture)
+		}
+	}
+	return textures
+}
+
+//parseColor reads an r g b colour. A single value is used for all three.
+func parseColor(fields []string) (noodle.Color, error) {
+	values, err := parseFloats(fields, 1)
+	if err != nil {
+		return noodle.Color{}, err
+	}
+	if len(values) < 3 {
+		values = []float32{values[0], values[0], values[0]}
+	}
+	return noodle.NewColorFromNormalized(noodle.Vector4{X: values[0], Y: values[1], Z: values[2], W: 1}), nil
+}
+
+//texturePath gets the path of a texture statement. Options such as -bm 1 come before the path, so it is the last field.
+func texturePath(fields []string) (string, error) {
+	if len(fields) < 2 {
+		return "", fmt.Errorf("%s is missing a path", fields[0])
+	}
+	return fields[len(fields)-1], nil
+}
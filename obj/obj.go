@@ -0,0 +1,316 @@
+//Package obj loads Wavefront OBJ models and their MTL materials into noodle meshes.
+//
+//Each group or object in the file is split by the material it uses, so every Group has a single Mesh and Material.
+// Polygons are triangulated as fans, and UVs are flipped so they start from the top left of the texture like the rest of noodle.
+package obj
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lachee/noodle"
+)
+
+//Model is a parsed OBJ file
+type Model struct {
+	Groups            []*Group             //Groups are the meshes in the order they appear in the file
+	Materials         map[string]*Material //Materials are the materials that have been added by name
+	MaterialLibraries []string             //MaterialLibraries are the paths of the MTL files the model uses, relative to the OBJ file
+}
+
+//Group is part of the model that is drawn with a single material
+type Group struct {
+	Name     string       //Name is the name of the group or object. It is empty if the file did not name it.
+	Material string       //Material is the name of the material the group uses. It is empty if the file did not set one.
+	Mesh     *noodle.Mesh //Mesh has not been uploaded yet
+}
+
+//GetMaterial gets the material the group uses, or nil if the model does not have it
+func (m *Model) GetMaterial(group *Group) *Material {
+	return m.Materials[group.Material]
+}
+
+//AddMaterials adds the materials to the model, replacing any with the same name
+func (m *Model) AddMaterials(materials map[string]*Material) {
+	for name, material := range materials {
+		m.Materials[name] = material
+	}
+}
+
+//vertexKey identifies a unique combination of the indices a face uses. -1 is a missing index.
+type vertexKey struct {
+	position, uv, normal int
+}
+
+//builder collects the faces of the current group
+type builder struct {
+	group    *Group
+	vertices map[vertexKey]uint32
+
+	hasUVs         bool
+	missingNormals bool
+}
+
+//parser holds the state while a file is read
+type parser struct {
+	model *Model
+
+	positions []noodle.Vector3
+	colors    []noodle.Color
+	uvs       []noodle.Vector2
+	normals   []noodle.Vector3
+
+	hasColors bool
+
+	name     string
+	material string
+	current  *builder
+	builders []*builder
+}
+
+//Parse reads an OBJ file. The material libraries it uses are listed in MaterialLibraries, but are not loaded.
+func Parse(data []byte) (*Model, error) {
+	p := &parser{model: &Model{Materials: make(map[string]*Material)}}
+
+	err := eachLine(data, func(line int, fields []string) error {
+		switch fields[0] {
+		case "v":
+			position, err := parseFloats(fields[1:], 3)
+			if err != nil {
+				return err
+			}
+			p.positions = append(p.positions, noodle.Vector3{X: position[0], Y: position[1], Z: position[2]})
+
+			//Some exporters put a colour after the position
+			if len(fields) >= 7 {
+				color, err := parseFloats(fields[4:7], 3)
+				if err != nil {
+					return err
+				}
+				p.colors = append(p.colors, noodle.NewColorFromNormalized(noodle.Vector4{X: color[0], Y: color[1], Z: color[2], W: 1}))
+				p.hasColors = true
+			} else {
+				p.colors = append(p.colors, noodle.White)
+			}
+
+		case "vt":
+			uv, err := parseFloats(fields[1:], 1)
+			if err != nil {
+				return err
+			}
+			v := float32(0)
+			if len(uv) > 1 {
+				v = uv[1]
+			}
+			p.uvs = append(p.uvs, noodle.Vector2{X: uv[0], Y: 1 - v})
+
+		case "vn":
+			normal, err := parseFloats(fields[1:], 3)
+			if err != nil {
+				return err
+			}
+			p.normals = append(p.normals, noodle.Vector3{X: normal[0], Y: normal[1], Z: normal[2]}.Normalize())
+
+		case "f":
+			return p.face(fields[1:])
+
+		case "g", "o":
+			p.name = strings.Join(fields[1:], " ")
+			p.current = nil
+
+		case "usemtl":
+			p.material = strings.Join(fields[1:], " ")
+			p.current = nil
+
+		case "mtllib":
+			p.model.MaterialLibraries = append(p.model.MaterialLibraries, fields[1:]...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range p.builders {
+		if err := b.finish(p.hasColors); err != nil {
+			return nil, err
+		}
+	}
+	return p.model, nil
+}
+
+//face adds a polygon to the current group, splitting it into a fan of triangles
+func (p *parser) face(corners []string) error {
+	if len(corners) < 3 {
+		return fmt.Errorf("a face needs at least 3 vertices, but has %d", len(corners))
+	}
+
+	b := p.builder()
+	indices := make([]uint32, len(corners))
+	for i, corner := range corners {
+		key, err := p.parseCorner(corner)
+		if err != nil {
+			return err
+		}
+		indices[i] = b.vertex(p, key)
+	}
+
+	for i := 1; i+1 < len(indices); i++ {
+		b.group.Mesh.Indices = append(b.group.Mesh.Indices, indices[0], indices[i], indices[i+1])
+	}
+	return nil
+}
+
+//builder gets the builder for the current group and material, starting a new one if they have changed
+func (p *parser) builder() *builder {
+	if p.current == nil {
+		group := &Group{Name: p.name, Material: p.material, Mesh: noodle.NewMesh()}
+		p.model.Groups = append(p.model.Groups, group)
+		p.current = &builder{group: group, vertices: make(map[vertexKey]uint32)}
+		p.builders = append(p.builders, p.current)
+	}
+	return p.current
+}
+
+//parseCorner reads a v, v/vt, v//vn or v/vt/vn corner of a face
+func (p *parser) parseCorner(corner string) (vertexKey, error) {
+	parts := strings.Split(corner, "/")
+	if len(parts) > 3 {
+		return vertexKey{}, fmt.Errorf("invalid face vertex %q", corner)
+	}
+
+	key := vertexKey{-1, -1, -1}
+	var err error
+	if key.position, err = resolveIndex(parts[0], len(p.positions)); err != nil {
+		return key, err
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if key.uv, err = resolveIndex(parts[1], len(p.uvs)); err != nil {
+			return key, err
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if key.normal, err = resolveIndex(parts[2], len(p.normals)); err != nil {
+			return key, err
+		}
+	}
+	return key, nil
+}
+
+//vertex gets the index of the vertex in the mesh, adding it if this is the first time it has been used
+func (b *builder) vertex(p *parser, key vertexKey) uint32 {
+	if index, ok := b.vertices[key]; ok {
+		return index
+	}
+
+	mesh := b.group.Mesh
+	index := uint32(len(mesh.Positions))
+	b.vertices[key] = index
+
+	mesh.Positions = append(mesh.Positions, p.positions[key.position])
+	mesh.Colors = append(mesh.Colors, p.colors[key.position])
+
+	uv := noodle.Vector2{}
+	if key.uv >= 0 {
+		uv = p.uvs[key.uv]
+		b.hasUVs = true
+	}
+	mesh.UVs = append(mesh.UVs, uv)
+
+	normal := noodle.Vector3{}
+	if key.normal >= 0 {
+		normal = p.normals[key.normal]
+	} else {
+		b.missingNormals = true
+	}
+	mesh.Normals = append(mesh.Normals, normal)
+	return index
+}
+
+//finish fills in what the file left out of the mesh. If any vertex is missing a normal, they are all generated.
+func (b *builder) finish(hasColors bool) error {
+	mesh := b.group.Mesh
+	if !hasColors {
+		mesh.Colors = nil
+	}
+	if b.missingNormals {
+		if err := mesh.GenerateNormals(); err != nil {
+			return err
+		}
+	}
+	if !b.hasUVs {
+		mesh.UVs = nil
+		return nil
+	}
+	return mesh.GenerateTangents()
+}
+
+//resolveIndex turns a 1 based index, or a negative index relative to the end, into a 0 based index
+func resolveIndex(value string, count int) (int, error) {
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if index < 0 {
+		index += count
+	} else {
+		index--
+	}
+
+	if index < 0 || index >= count {
+		return 0, fmt.Errorf("index %s is out of range", value)
+	}
+	return index, nil
+}
+
+//parseFloats parses the fields as floats, requiring at least min of them
+func parseFloats(fields []string, min int) ([]float32, error) {
+	if len(fields) < min {
+		return nil, fmt.Errorf("expected %d values but got %d", min, len(fields))
+	}
+
+	values := make([]float32, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = float32(value)
+	}
+	return values, nil
+}
+
+//eachLine calls the function with the fields of every line that is not empty or a comment. Lines ending in a backslash are joined with the next.
+func eachLine(data []byte, fn func(line int, fields []string) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+
+	number, text := 0, ""
+	for scanner.Scan() {
+		number++
+		line := scanner.Text()
+		if strings.HasSuffix(line, "\\") {
+			text += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		text += line
+
+		if comment := strings.IndexByte(text, '#'); comment >= 0 {
+			text = text[:comment]
+		}
+
+		fields := strings.Fields(text)
+		text = ""
+		if len(fields) == 0 {
+			continue
+		}
+		if err := fn(number, fields); err != nil {
+			return fmt.Errorf("line %d: %v", number, err)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,143 @@
+package obj
+
+import (
+	"os"
+	"testing"
+
+	"github.com/lachee/noodle"
+)
+
+//parseFile parses a model from the testdata directory
+func parseFile(t *testing.T, name string) *Model {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestParseSplitsGroupsByMaterial(t *testing.T) {
+	model := parseFile(t, "scene.obj")
+	if len(model.MaterialLibraries) != 1 || model.MaterialLibraries[0] != "scene.mtl" {
+		t.Errorf("unexpected material libraries %v", model.MaterialLibraries)
+	}
+
+	expected := []struct{ name, material string }{{"Quad", "Red"}, {"Quad", "Blue"}, {"Flat", "Blue"}}
+	if len(model.Groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %d", len(expected), len(model.Groups))
+	}
+	for i, group := range model.Groups {
+		if group.Name != expected[i].name || group.Material != expected[i].material {
+			t.Errorf("group %d: expected %v, got %q using %q", i, expected[i], group.Name, group.Material)
+		}
+	}
+}
+
+func TestParseTriangulatesFans(t *testing.T) {
+	mesh := parseFile(t, "scene.obj").Groups[0].Mesh
+	if len(mesh.Positions) != 4 {
+		t.Fatalf("expected the 4 corners to be shared, got %d vertices", len(mesh.Positions))
+	}
+
+	indices := []uint32{0, 1, 2, 0, 2, 3}
+	if len(mesh.Indices) != len(indices) {
+		t.Fatalf("expected the indices %v, got %v", indices, mesh.Indices)
+	}
+	for i := range indices {
+		if mesh.Indices[i] != indices[i] {
+			t.Fatalf("expected the indices %v, got %v", indices, mesh.Indices)
+		}
+	}
+
+	//UVs are flipped to start from the top left, and the file's normals are kept
+	if mesh.UVs[0] != (noodle.Vector2{X: 0, Y: 1}) || mesh.UVs[2] != (noodle.Vector2{X: 1, Y: 0}) {
+		t.Errorf("unexpected UVs %v", mesh.UVs)
+	}
+	if mesh.Normals[0] != (noodle.Vector3{Y: 1}) || len(mesh.Tangents) != 4 || mesh.Colors != nil {
+		t.Errorf("unexpected attributes %+v", mesh)
+	}
+}
+
+func TestParseNegativeIndices(t *testing.T) {
+	model := parseFile(t, "scene.obj")
+	quad, triangle := model.Groups[0].Mesh, model.Groups[1].Mesh
+
+	//-4 is the first of the 4 positions, so the triangle uses the first 3 corners of the quad
+	if len(triangle.Positions) != 3 || len(triangle.Indices) != 3 {
+		t.Fatalf("expected a single triangle, got %d vertices and %d indices", len(triangle.Positions), len(triangle.Indices))
+	}
+	for i := 0; i < 3; i++ {
+		if triangle.Positions[i] != quad.Positions[i] || triangle.UVs[i] != quad.UVs[i] || triangle.Normals[i] != quad.Normals[i] {
+			t.Errorf("vertex %d does not match the quad", i)
+		}
+	}
+}
+
+func TestParseGeneratesMissingNormals(t *testing.T) {
+	mesh := parseFile(t, "scene.obj").Groups[2].Mesh
+	if mesh.UVs != nil || mesh.Tangents != nil {
+		t.Errorf("expected no UVs or tangents, got %v and %v", mesh.UVs, mesh.Tangents)
+	}
+
+	//Only one corner has a normal, so all of them are generated from the face instead
+	for i, normal := range mesh.Normals {
+		if normal != (noodle.Vector3{Z: 1}) {
+			t.Errorf("normal %d: expected the face normal, got %v", i, normal)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	files := map[string]string{
+		"line 2: index 4 is out of range":                     "v 0 0 0\nf 1 1 4\n",
+		"line 1: a face needs at least 3 vertices, but has 2": "f 1 2\n",
+		"line 2: invalid face vertex \"1/1/1/1\"":             "v 0 0 0\nf 1/1/1/1 1 1\n",
+	}
+	for expected, file := range files {
+		if _, err := Parse([]byte(file)); err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestParseMTL(t *testing.T) {
+	data, err := os.ReadFile("testdata/scene.mtl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	materials, err := ParseMTL(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	red, blue := materials["Red"], materials["Blue"]
+	if red == nil || blue == nil {
+		t.Fatalf("expected Red and Blue, got %v", materials)
+	}
+	if red.Diffuse != (noodle.Color{R: 255, A: 255}) || red.DiffuseTexture != "textures/red.png" || red.Opacity != 1 {
+		t.Errorf("unexpected red %+v", red)
+	}
+	if blue.Opacity != 0.5 || blue.NormalTexture != "textures/normal.png" || len(blue.Textures()) != 1 {
+		t.Errorf("unexpected blue %+v", blue)
+	}
+}
+
+func TestLoadResolvesMaterials(t *testing.T) {
+	previous := noodle.Files
+	noodle.Files = os.DirFS("testdata")
+	defer func() { noodle.Files = previous }()
+
+	model, err := Load("/scene.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	material := model.GetMaterial(model.Groups[0])
+	if material == nil || material.DiffuseTexture != "/textures/red.png" {
+		t.Fatalf("expected the texture to be relative to the MTL file, got %+v", material)
+	}
+}
@@ -0,0 +1,131 @@
+package pack
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//Builder collects files and writes them as a pack
+type Builder struct {
+	entries []*Entry
+	data    [][]byte
+	index   map[string]bool
+}
+
+//NewBuilder creates an empty builder
+func NewBuilder() *Builder {
+	return &Builder{index: make(map[string]bool)}
+}
+
+//Add adds a file to the pack. The name is cleaned, so it has no leading slash or dot segments. When compress is true the
+// file is stored with DEFLATE, unless that would not make it smaller, which is common for PNG and JPEG images.
+func (b *Builder) Add(name string, data []byte, compress bool) error {
+	name = cleanName(filepath.ToSlash(name))
+	if name == "" {
+		return fmt.Errorf("pack: invalid name %q", name)
+	}
+	if b.index[name] {
+		return fmt.Errorf("pack: %q has already been added", name)
+	}
+	if len(name) > math.MaxUint16 || uint64(len(data)) > math.MaxUint32 {
+		return fmt.Errorf("pack: %q is too large", name)
+	}
+
+	entry := &Entry{Name: name, Size: len(data), stored: len(data)}
+	stored := data
+	if compress {
+		var buffer bytes.Buffer
+		writer, _ := flate.NewWriter(&buffer, flate.BestCompression)
+		writer.Write(data)
+		writer.Close()
+		if buffer.Len() < len(data) {
+			entry.Compressed, entry.stored, stored = true, buffer.Len(), buffer.Bytes()
+		}
+	}
+
+	b.entries = append(b.entries, entry)
+	b.data = append(b.data, stored)
+	b.index[name] = true
+	return nil
+}
+
+//AddDir adds every file in the directory and its subdirectories, named by their path relative to the directory.
+// Hidden files and directories, which start with a dot, are skipped.
+func (b *Builder) AddDir(dir string, compress bool) error {
+	return filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if file != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return b.Add(name, data, compress)
+	})
+}
+
+//Entries gets the files that have been added, in the order they will be stored
+func (b *Builder) Entries() []*Entry {
+	return b.entries
+}
+
+//WriteTo writes the pack
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	var index bytes.Buffer
+	index.Write(magic)
+	binary.Write(&index, binary.LittleEndian, uint32(Version))
+	binary.Write(&index, binary.LittleEndian, uint32(len(b.entries)))
+
+	offset := 0
+	for _, entry := range b.entries {
+		if uint64(offset+entry.stored) > math.MaxUint32 {
+			return 0, fmt.Errorf("pack: the pack is larger than 4GB")
+		}
+
+		flags := uint8(0)
+		if entry.Compressed {
+			flags |= flagCompressed
+		}
+		binary.Write(&index, binary.LittleEndian, uint16(len(entry.Name)))
+		index.WriteString(entry.Name)
+		binary.Write(&index, binary.LittleEndian, flags)
+		binary.Write(&index, binary.LittleEndian, []uint32{uint32(offset), uint32(entry.stored), uint32(entry.Size)})
+		offset += entry.stored
+	}
+
+	written, err := w.Write(index.Bytes())
+	total := int64(written)
+	if err != nil {
+		return total, err
+	}
+	for _, data := range b.data {
+		written, err = w.Write(data)
+		total += int64(written)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
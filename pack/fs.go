@@ -0,0 +1,28 @@
+package pack
+
+import (
+	"io/fs"
+
+	"github.com/lachee/noodle/internal/memfs"
+)
+
+//Open opens a file or directory in the pack, so the pack can be used as noodle.Files or with anything else that takes an
+// fs.FS. Directories are implied by the names of the files in them.
+func (p *Pack) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if entry := p.index[name]; entry != nil {
+		data, err := p.read(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return memfs.OpenFile(name, data), nil
+	}
+
+	files := make(map[string]int64, len(p.entries))
+	for _, entry := range p.entries {
+		files[entry.Name] = int64(entry.Size)
+	}
+	return memfs.OpenDir(name, files)
+}
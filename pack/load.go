@@ -0,0 +1,62 @@
+package pack
+
+import (
+	"io/ioutil"
+
+	"github.com/lachee/noodle"
+)
+
+//Open reads a pack from a file on disk
+func Open(filename string) (*Pack, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+//Load reads a pack from noodle.Files
+func Load(url string) (*Pack, error) {
+	data, err := noodle.ReadFile(url)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+//LoadImage decodes an image in the pack, like noodle.LoadImage does for a URL
+func (p *Pack) LoadImage(name string) (*noodle.Image, error) {
+	data, err := p.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return noodle.LoadImageData(data)
+}
+
+//ShaderLoader loads shader files from the pack, for noodle.PreprocessShader and noodle.ShaderLibrary
+func (p *Pack) ShaderLoader() noodle.ShaderLoader {
+	return p.ReadString
+}
+
+//LoadShader loads and preprocesses a vertex and fragment shader in the pack, like noodle.LoadShaderFromURL does for URLs.
+// They can #include other files in the pack.
+func (p *Pack) LoadShader(vertName, fragName string) (*noodle.Shader, error) {
+	vert, err := noodle.PreprocessShader(p.ShaderLoader(), vertName, nil)
+	if err != nil {
+		return nil, err
+	}
+	frag, err := noodle.PreprocessShader(p.ShaderLoader(), fragName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return noodle.LoadShaderSource(vert, frag)
+}
+
+//LoadFontBitmap loads an image of characters in the pack as a font, like noodle.LoadFontBitmap
+func (p *Pack) LoadFontBitmap(name, charset string, charPerLines, noLines int) (*noodle.Font, error) {
+	image, err := p.LoadImage(name)
+	if err != nil {
+		return nil, err
+	}
+	return noodle.LoadFontBitmap(image, charset, charPerLines, noLines), nil
+}
@@ -0,0 +1,173 @@
+//Package pack reads and writes noodle pack files, which bundle the images, shaders and fonts of an application into a single
+// file so they can be downloaded with one request.
+//
+//A pack starts with an index of every file, followed by the data of the files one after another. Files can be compressed
+// with DEFLATE. Numbers are little endian:
+//
+//	magic    [4]byte  "NPAK"
+//	version  uint32
+//	count    uint32
+//	count entries of
+//		length   uint16
+//		name     [length]byte  the path of the file, separated by forward slashes
+//		flags    uint8         1 if the data is compressed
+//		offset   uint32        where the data starts, from the end of the index
+//		stored   uint32        the size of the data in the pack
+//		size     uint32        the size of the file once it is decompressed
+//	data
+package pack
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+//Version is the version of the format that is written and read
+const Version = 1
+
+//magic starts every pack
+var magic = []byte("NPAK")
+
+//flagCompressed marks the data of an entry as DEFLATE compressed
+const flagCompressed = 1
+
+var (
+	//ErrInvalidPack is returned when the data is not a noodle pack, or its index does not fit the data
+	ErrInvalidPack = errors.New("invalid noodle pack")
+	//ErrUnsupportedVersion is returned when the pack was not written with a version of the format this package reads
+	ErrUnsupportedVersion = errors.New("unsupported pack version")
+)
+
+//Entry describes a file in the pack
+type Entry struct {
+	Name       string
+	Compressed bool //Compressed is true if the file is stored with DEFLATE
+	Size       int  //Size is the size of the file once it is decompressed
+
+	offset int
+	stored int
+}
+
+//Pack is a parsed pack file. Files are decompressed each time they are read.
+type Pack struct {
+	entries []*Entry
+	index   map[string]*Entry
+	data    []byte
+}
+
+//Parse reads the index of a pack. The data is kept, not copied.
+func Parse(data []byte) (*Pack, error) {
+	reader := bytes.NewReader(data)
+	header := make([]byte, len(magic))
+	if _, err := reader.Read(header); err != nil || !bytes.Equal(header, magic) {
+		return nil, ErrInvalidPack
+	}
+
+	var version, count uint32
+	if binary.Read(reader, binary.LittleEndian, &version) != nil || binary.Read(reader, binary.LittleEndian, &count) != nil {
+		return nil, ErrInvalidPack
+	}
+	if version == 0 || version > Version {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, version)
+	}
+
+	p := &Pack{index: make(map[string]*Entry)}
+	for i := uint32(0); i < count; i++ {
+		var length uint16
+		if binary.Read(reader, binary.LittleEndian, &length) != nil || int(length) > reader.Len() {
+			return nil, ErrInvalidPack
+		}
+		name := make([]byte, length)
+		reader.Read(name)
+
+		var fields struct {
+			Flags                uint8
+			Offset, Stored, Size uint32
+		}
+		if binary.Read(reader, binary.LittleEndian, &fields) != nil {
+			return nil, ErrInvalidPack
+		}
+
+		entry := &Entry{
+			Name:       string(name),
+			Compressed: fields.Flags&flagCompressed != 0,
+			Size:       int(fields.Size),
+			offset:     int(fields.Offset),
+			stored:     int(fields.Stored),
+		}
+		if _, exists := p.index[entry.Name]; exists {
+			return nil, fmt.Errorf("%w: %q is in the pack twice", ErrInvalidPack, entry.Name)
+		}
+		p.entries = append(p.entries, entry)
+		p.index[entry.Name] = entry
+	}
+
+	//The offsets are from the end of the index
+	p.data = data[len(data)-reader.Len():]
+	for _, entry := range p.entries {
+		if entry.offset+entry.stored > len(p.data) {
+			return nil, fmt.Errorf("%w: %q is outside of the data", ErrInvalidPack, entry.Name)
+		}
+	}
+	return p, nil
+}
+
+//cleanName makes a name match the names of entries, which have no leading slash or dot segments
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+//Entries gets the files in the order they are stored
+func (p *Pack) Entries() []*Entry {
+	return p.entries
+}
+
+//Entry gets a file by name, or nil if the pack does not have it
+func (p *Pack) Entry(name string) *Entry {
+	return p.index[cleanName(name)]
+}
+
+//ReadFile gets a copy of the contents of a file. The name has to be a valid fs path, such as "shader/ui.vert", as the
+// pack is an fs.FS. Files the pack does not have return an error that matches os.ErrNotExist.
+func (p *Pack) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return p.read(name)
+}
+
+//read gets a copy of the contents of a file, cleaning the name first
+func (p *Pack) read(name string) ([]byte, error) {
+	entry := p.Entry(name)
+	if entry == nil {
+		return nil, fmt.Errorf("pack: %s: %w", name, os.ErrNotExist)
+	}
+
+	stored := p.data[entry.offset : entry.offset+entry.stored]
+	if !entry.Compressed {
+		return append([]byte(nil), stored...), nil
+	}
+
+	data, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(stored)))
+	if err != nil {
+		return nil, fmt.Errorf("pack: %s: %w", name, err)
+	}
+	if len(data) != entry.Size {
+		return nil, fmt.Errorf("%w: %q is not the size the index says", ErrInvalidPack, name)
+	}
+	return data, nil
+}
+
+//ReadString gets the contents of a file as a string. Unlike ReadFile, the name is cleaned, so "/shader/../ui.vert" is "ui.vert".
+func (p *Pack) ReadString(name string) (string, error) {
+	data, err := p.read(name)
+	return string(data), err
+}
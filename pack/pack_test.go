@@ -0,0 +1,105 @@
+package pack
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+//files are packed by buildPack. The shader is long enough to be compressed.
+var files = map[string]string{
+	"tile.png":          "not really a png",
+	"shader/ui.vert":    strings.Repeat("//padding\n", 50) + "void main() {}",
+	"shader/lib/a.glsl": "uniform mat4 uModel;",
+}
+
+//buildPack writes a pack of the files, compressing them if they get smaller
+func buildPack(t *testing.T) []byte {
+	t.Helper()
+	builder := NewBuilder()
+	for name, data := range files {
+		if err := builder.Add(name, []byte(data), true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buffer bytes.Buffer
+	written, err := builder.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buffer.Len()) {
+		t.Fatalf("WriteTo wrote %d bytes but said %d", buffer.Len(), written)
+	}
+	return buffer.Bytes()
+}
+
+func TestPackRoundTrip(t *testing.T) {
+	pack, err := Parse(buildPack(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pack.Entries()) != len(files) {
+		t.Fatalf("expected %d entries, got %d", len(files), len(pack.Entries()))
+	}
+	for name, expected := range files {
+		data, err := pack.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, data)
+		}
+	}
+
+	if entry := pack.Entry("/shader/../shader/ui.vert"); entry == nil || !entry.Compressed || entry.Size != len(files["shader/ui.vert"]) {
+		t.Errorf("expected the shader to be compressed, got %+v", entry)
+	}
+	if _, err := pack.ReadFile("missing.png"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestPackBuilderRejectsDuplicates(t *testing.T) {
+	builder := NewBuilder()
+	if err := builder.Add("shader/ui.vert", nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Add("/shader/../shader/ui.vert", nil, false); err == nil {
+		t.Error("expected an error for the same name twice")
+	}
+}
+
+func TestParseRejectsTruncatedPacks(t *testing.T) {
+	data := buildPack(t)
+	for length := 0; length < len(data); length++ {
+		if _, err := Parse(data[:length]); !errors.Is(err, ErrInvalidPack) {
+			t.Fatalf("%d of %d bytes: expected ErrInvalidPack, got %v", length, len(data), err)
+		}
+	}
+}
+
+func TestParseRejectsVersions(t *testing.T) {
+	for _, version := range []uint32{0, Version + 1} {
+		data := buildPack(t)
+		binary.LittleEndian.PutUint32(data[len(magic):], version)
+		if _, err := Parse(data); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("version %d: expected ErrUnsupportedVersion, got %v", version, err)
+		}
+	}
+}
+
+func TestPackFS(t *testing.T) {
+	pack, err := Parse(buildPack(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(pack, "tile.png", "shader/ui.vert", "shader/lib/a.glsl"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,69 @@
+package noodle
+
+//EventType is the kind of event a Platform raises
+type EventType int
+
+const (
+	//EventMouseMove is raised when the cursor moves. X and Y hold the new position.
+	EventMouseMove EventType = iota
+	//EventMouseDown is raised when a mouse Button is pressed
+	EventMouseDown
+	//EventMouseUp is raised when a mouse Button is released
+	EventMouseUp
+	//EventMouseScroll is raised when the wheel is scrolled by Delta
+	EventMouseScroll
+	//EventKeyDown is raised when a Key is pressed. Repeats are not raised.
+	EventKeyDown
+	//EventKeyUp is raised when a Key is released
+	EventKeyUp
+	//EventContextLost is raised when the GL context is lost, destroying every object in it
+	EventContextLost
+	//EventContextRestored is raised when the GL context is available again and its objects need to be recreated
+	EventContextRestored
+	//EventResize is raised when the size of the canvas or the pixel ratio changes. The new size is read from the Platform.
+	EventResize
+	//EventPause is raised when the page is hidden
+	EventPause
+	//EventResume is raised when the page is visible again
+	EventResume
+	//EventFocus is raised when the canvas gains focus
+	EventFocus
+	//EventBlur is raised when the canvas loses focus
+	EventBlur
+)
+
+//Event is raised by the Platform when the user interacts with it
+type Event struct {
+	Type   EventType
+	X      int     //X is the cursor position in pixels
+	Y      int     //Y is the cursor position in pixels
+	Button int     //Button is the mouse button that changed
+	Key    Key     //Key is the key that changed
+	Delta  float32 //Delta is how far the wheel scrolled
+}
+
+//Platform provides the canvas noodle draws to, and the frames and events that drive the Application.
+type Platform interface {
+	//Setup prepares the canvas and creates the GL context that draws to it
+	Setup() (GLContext, error)
+
+	//Size gets the size of the canvas in device pixels
+	Size() (int, int)
+
+	//SetSize resizes the canvas to the size in CSS pixels, returning the size in device pixels it actually became.
+	// The canvas will no longer follow the size of the window.
+	SetSize(width, height int) (int, int)
+
+	//PixelRatio gets how many device pixels there are for each CSS pixel
+	PixelRatio() float64
+
+	//RequestFrame schedules the callback to be called on the next frame with the current time in milliseconds.
+	// Only the latest callback is kept.
+	RequestFrame(callback func(time float64))
+
+	//Listen starts sending events to the handler
+	Listen(handler func(evt Event))
+
+	//Release stops all events and frames, freeing anything the platform holds on to
+	Release()
+}
@@ -0,0 +1,143 @@
+package noodle
+
+//HeadlessPlatform is a Platform that runs without a browser. It draws to a HeadlessGL and is driven by a manual clock,
+// so an Application can be stepped frame by frame in tests and tools.
+type HeadlessPlatform struct {
+	gl      *HeadlessGL
+	width   int
+	height  int
+	ratio   float64
+	time    float64
+	frame   func(time float64)
+	handler func(evt Event)
+}
+
+//HeadlessPlatform must satisfy the Platform
+var _ Platform = (*HeadlessPlatform)(nil)
+
+//NewHeadlessPlatform creates a new headless platform with a canvas of the given size
+func NewHeadlessPlatform(width, height int) *HeadlessPlatform {
+	return &HeadlessPlatform{
+		gl:     NewHeadlessGL(),
+		width:  width,
+		height: height,
+		ratio:  1,
+	}
+}
+
+//GL gets the headless GL context the platform draws to
+func (p *HeadlessPlatform) GL() *HeadlessGL { return p.gl }
+
+//Time gets the current time of the clock in milliseconds
+func (p *HeadlessPlatform) Time() float64 { return p.time }
+
+//Setup returns the headless GL context
+func (p *HeadlessPlatform) Setup() (GLContext, error) { return p.gl, nil }
+
+//Size gets the size of the canvas
+func (p *HeadlessPlatform) Size() (int, int) { return p.width, p.height }
+
+//SetSize sets the size of the canvas in CSS pixels
+func (p *HeadlessPlatform) SetSize(width, height int) (int, int) {
+	p.width = int(float64(width) * p.ratio)
+	p.height = int(float64(height) * p.ratio)
+	return p.width, p.height
+}
+
+//PixelRatio gets how many device pixels there are for each CSS pixel
+func (p *HeadlessPlatform) PixelRatio() float64 { return p.ratio }
+
+//Resize resizes the canvas to the size in device pixels and tells the listener, as if the window had been resized
+func (p *HeadlessPlatform) Resize(width, height int) {
+	p.width = width
+	p.height = height
+	p.Dispatch(Event{Type: EventResize})
+}
+
+//SetPixelRatio changes the pixel ratio, scaling the canvas with it, and tells the listener as if the window had moved to another display
+func (p *HeadlessPlatform) SetPixelRatio(ratio float64) {
+	p.width = int(float64(p.width) / p.ratio * ratio)
+	p.height = int(float64(p.height) / p.ratio * ratio)
+	p.ratio = ratio
+	p.Dispatch(Event{Type: EventResize})
+}
+
+//RequestFrame stores the callback until the next Step
+func (p *HeadlessPlatform) RequestFrame(callback func(time float64)) {
+	p.frame = callback
+}
+
+//Listen stores the handler so Dispatch can send events to it
+func (p *HeadlessPlatform) Listen(handler func(evt Event)) {
+	p.handler = handler
+}
+
+//Release drops the pending frame and the event handler
+func (p *HeadlessPlatform) Release() {
+	p.frame = nil
+	p.handler = nil
+}
+
+//Step advances the clock by the milliseconds and runs the pending frame. Returns false if no frame was requested.
+// The calls recorded by the GL are reset before the frame runs, so afterwards they are only the calls the frame made.
+func (p *HeadlessPlatform) Step(milliseconds float64) bool {
+	p.time += milliseconds
+	frame := p.frame
+	if frame == nil {
+		return false
+	}
+
+	p.frame = nil
+	p.gl.ResetCalls()
+	frame(p.time)
+	return true
+}
+
+//StepN steps the given number of frames, returning how many actually ran
+func (p *HeadlessPlatform) StepN(frames int, milliseconds float64) int {
+	count := 0
+	for i := 0; i < frames; i++ {
+		if !p.Step(milliseconds) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+//Dispatch sends the event to the listener as if the user had performed it
+func (p *HeadlessPlatform) Dispatch(evt Event) {
+	if p.handler != nil {
+		p.handler(evt)
+	}
+}
+
+//SetVisible shows or hides the page, pausing and resuming the application
+func (p *HeadlessPlatform) SetVisible(visible bool) {
+	if visible {
+		p.Dispatch(Event{Type: EventResume})
+	} else {
+		p.Dispatch(Event{Type: EventPause})
+	}
+}
+
+//SetFocused focuses or blurs the canvas
+func (p *HeadlessPlatform) SetFocused(focused bool) {
+	if focused {
+		p.Dispatch(Event{Type: EventFocus})
+	} else {
+		p.Dispatch(Event{Type: EventBlur})
+	}
+}
+
+//LoseContext loses the GL context and tells the listener, as if the browser had taken it away
+func (p *HeadlessPlatform) LoseContext() {
+	p.gl.LoseContext()
+	p.Dispatch(Event{Type: EventContextLost})
+}
+
+//RestoreContext restores the GL context and tells the listener so it can recreate its objects
+func (p *HeadlessPlatform) RestoreContext() {
+	p.gl.RestoreContext()
+	p.Dispatch(Event{Type: EventContextRestored})
+}
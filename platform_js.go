@@ -0,0 +1,313 @@
+package noodle
+
+import (
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+//DOMPlatform runs noodle in a canvas element of the current document
+type DOMPlatform struct {
+	//WebGL2 requests a WebGL2 context. If the browser does not support it, a WebGL1 context is used instead.
+	// Use GL.Version() to find out which was obtained.
+	WebGL2 bool
+
+	canvasID  string
+	document  js.Value
+	canvas    js.Value
+	gl        *WebGL
+	listeners []domListener
+
+	autoSize      bool        //autoSize makes the canvas follow the size of the body
+	cssWidth      int         //cssWidth is the width of the canvas in CSS pixels
+	cssHeight     int         //cssHeight is the height of the canvas in CSS pixels
+	ratioListener domListener //ratioListener watches for the pixel ratio to change
+
+	frameFunc     js.Func
+	frameRequest  js.Value
+	framePending  bool //framePending is true while a frame has been requested but not yet run
+	frameCallback func(time float64)
+}
+
+//domListener is a event listener that was added to a element
+type domListener struct {
+	target js.Value
+	event  string
+	fn     js.Func
+}
+
+//DOMPlatform must satisfy the Platform
+var _ Platform = (*DOMPlatform)(nil)
+
+//NewDOMPlatform creates a platform that draws to the canvas with the given ID. If no element exists with the ID, a new canvas is added to the body.
+func NewDOMPlatform(canvasID string) *DOMPlatform {
+	return &DOMPlatform{canvasID: canvasID}
+}
+
+//Canvas gets the canvas element
+func (p *DOMPlatform) Canvas() js.Value { return p.canvas }
+
+//Setup finds the canvas, sizes it to cover the body and creates the WebGL context
+func (p *DOMPlatform) Setup() (GLContext, error) {
+	p.document = js.Global().Get("document")
+	p.canvas = p.document.Call("getElementById", p.canvasID)
+	if p.canvas.IsNull() || p.canvas.IsUndefined() {
+		p.canvas = p.document.Call("createElement", "canvas")
+		p.canvas.Set("id", p.canvasID)
+		p.document.Get("body").Call("appendChild", p.canvas)
+	}
+
+	//The canvas can only receive focus, and so keys, if it has a tab index. It takes the focus if nothing else has it.
+	if !p.canvas.Call("hasAttribute", "tabindex").Bool() {
+		p.canvas.Set("tabIndex", 0)
+	}
+	if active := p.document.Get("activeElement"); active.IsNull() || active.Equal(p.document.Get("body")) {
+		p.canvas.Call("focus")
+	}
+
+	//Set the width and height of the canvas to conver the entire screen
+	p.autoSize = true
+	p.fitToBody()
+
+	//Get the GL context, trying WebGL2 first if it was asked for
+	contextOptions := js.Global().Get("JSON").Call("parse", "{ \"desynchronized\": true }")
+	context, version := js.Null(), 1
+	if p.WebGL2 {
+		context, version = p.canvas.Call("getContext", "webgl2", contextOptions), 2
+	}
+	if context.IsUndefined() || context.IsNull() {
+		context, version = p.canvas.Call("getContext", "webgl", contextOptions), 1
+	}
+	if context.IsUndefined() || context.IsNull() {
+		context = p.canvas.Call("getContext", "experimental-webgl", contextOptions)
+	}
+	if context.IsUndefined() || context.IsNull() {
+		js.Global().Call("alert", "browser might not support webgl")
+		return nil, errors.New("browser might not support webgl")
+	}
+
+	//Prepare the frame callback
+	p.frameFunc = js.FuncOf(p.onAnimationFrame)
+	p.gl = newWebGL(context, version)
+	return p.gl, nil
+}
+
+//Size gets the size of the canvas in device pixels
+func (p *DOMPlatform) Size() (int, int) {
+	return p.canvas.Get("width").Int(), p.canvas.Get("height").Int()
+}
+
+//SetSize sets the size of the canvas in CSS pixels. The canvas will no longer follow the size of the body.
+func (p *DOMPlatform) SetSize(width, height int) (int, int) {
+	p.autoSize = false
+	return p.resizeCanvas(width, height)
+}
+
+//PixelRatio gets the devicePixelRatio of the window
+func (p *DOMPlatform) PixelRatio() float64 {
+	ratio := js.Global().Get("devicePixelRatio")
+	if ratio.IsUndefined() || ratio.Float() <= 0 {
+		return 1
+	}
+	return ratio.Float()
+}
+
+//fitToBody resizes the canvas to cover the body
+func (p *DOMPlatform) fitToBody() {
+	width := p.document.Get("body").Get("clientWidth").Int()
+	height := p.document.Get("body").Get("clientHeight").Int()
+	p.resizeCanvas(width, height)
+}
+
+//resizeCanvas sets the CSS size of the canvas and scales its drawing buffer by the pixel ratio so it stays sharp on HiDPI displays
+func (p *DOMPlatform) resizeCanvas(width, height int) (int, int) {
+	ratio := p.PixelRatio()
+	p.cssWidth, p.cssHeight = width, height
+	p.canvas.Get("style").Set("width", fmt.Sprintf("%dpx", width))
+	p.canvas.Get("style").Set("height", fmt.Sprintf("%dpx", height))
+	p.canvas.Set("width", int(float64(width)*ratio))
+	p.canvas.Set("height", int(float64(height)*ratio))
+	return p.Size()
+}
+
+//onResize resizes the canvas after the window or pixel ratio changed, then tells the handler
+func (p *DOMPlatform) onResize(handler func(evt Event)) {
+	if p.autoSize {
+		p.fitToBody()
+	} else {
+		p.resizeCanvas(p.cssWidth, p.cssHeight)
+	}
+	handler(Event{Type: EventResize})
+}
+
+//watchPixelRatio listens for the current pixel ratio to stop matching. Browsers only raise a change on a media query,
+// so a new query has to be made for every ratio.
+func (p *DOMPlatform) watchPixelRatio(handler func(evt Event)) {
+	p.unwatchPixelRatio()
+
+	matchMedia := js.Global().Get("matchMedia")
+	if matchMedia.IsUndefined() {
+		return
+	}
+
+	query := js.Global().Call("matchMedia", fmt.Sprintf("(resolution: %vdppx)", p.PixelRatio()))
+	fn := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		p.onResize(handler)
+		p.watchPixelRatio(handler)
+		return nil
+	})
+	query.Call("addEventListener", "change", fn)
+	p.ratioListener = domListener{query, "change", fn}
+}
+
+//unwatchPixelRatio removes the pixel ratio listener
+func (p *DOMPlatform) unwatchPixelRatio() {
+	if p.ratioListener.target.IsUndefined() {
+		return
+	}
+	p.ratioListener.target.Call("removeEventListener", p.ratioListener.event, p.ratioListener.fn)
+	p.ratioListener.fn.Release()
+	p.ratioListener = domListener{}
+}
+
+//RequestFrame requests a new animation frame from the browser. If a frame is already pending, it will call the new
+// callback instead of another frame being requested, so there is only ever one frame loop.
+func (p *DOMPlatform) RequestFrame(callback func(time float64)) {
+	p.frameCallback = callback
+	if p.framePending {
+		return
+	}
+
+	p.framePending = true
+	p.frameRequest = js.Global().Call("requestAnimationFrame", p.frameFunc)
+}
+
+//onAnimationFrame is called by the browser when a frame is ready
+func (p *DOMPlatform) onAnimationFrame(this js.Value, args []js.Value) interface{} {
+	p.framePending = false
+	if p.frameCallback != nil {
+		p.frameCallback(args[0].Float())
+	}
+	return nil
+}
+
+//Listen adds the mouse, keyboard and context listeners to the canvas and the resize listeners to the window. Keys only
+// go to the canvas that has focus, so several canvases on one page do not all get every key.
+func (p *DOMPlatform) Listen(handler func(evt Event)) {
+
+	//Cursor Moved. The offset is in CSS pixels, so it is scaled to match the canvas.
+	p.addEventListener(p.canvas, "mousemove", func(this js.Value, args []js.Value) interface{} {
+		evt := args[0]
+		ratio := p.PixelRatio()
+		handler(Event{Type: EventMouseMove, X: int(evt.Get("offsetX").Float() * ratio), Y: int(evt.Get("offsetY").Float() * ratio)})
+		return nil
+	})
+
+	//Mouse Up
+	p.addEventListener(p.canvas, "mouseup", func(this js.Value, args []js.Value) interface{} {
+		evt := args[0]
+		handler(Event{Type: EventMouseUp, Button: evt.Get("button").Int()})
+		return nil
+	})
+
+	//Mouse Down
+	p.addEventListener(p.canvas, "mousedown", func(this js.Value, args []js.Value) interface{} {
+		evt := args[0]
+		handler(Event{Type: EventMouseDown, Button: evt.Get("button").Int()})
+		return nil
+	})
+
+	//Mouse Scroll
+	p.addEventListener(p.canvas, "wheel", func(this js.Value, args []js.Value) interface{} {
+		evt := args[0]
+		handler(Event{Type: EventMouseScroll, Delta: float32(evt.Get("deltaY").Float())})
+		return nil
+	})
+
+	//Context Lost. The default has to be prevented, otherwise the browser will never restore it.
+	p.addEventListener(p.canvas, "webglcontextlost", func(this js.Value, args []js.Value) interface{} {
+		args[0].Call("preventDefault")
+		handler(Event{Type: EventContextLost})
+		return nil
+	})
+
+	//Context Restored
+	p.addEventListener(p.canvas, "webglcontextrestored", func(this js.Value, args []js.Value) interface{} {
+		p.gl.resetExtensions()
+		handler(Event{Type: EventContextRestored})
+		return nil
+	})
+
+	//Window Resized
+	p.addEventListener(js.Global(), "resize", func(this js.Value, args []js.Value) interface{} {
+		p.onResize(handler)
+		return nil
+	})
+	p.watchPixelRatio(handler)
+
+	//Page Hidden or Shown
+	p.addEventListener(p.document, "visibilitychange", func(this js.Value, args []js.Value) interface{} {
+		if p.document.Get("hidden").Bool() {
+			handler(Event{Type: EventPause})
+		} else {
+			handler(Event{Type: EventResume})
+		}
+		return nil
+	})
+
+	//Canvas Focused
+	p.addEventListener(p.canvas, "focus", func(this js.Value, args []js.Value) interface{} {
+		handler(Event{Type: EventFocus})
+		return nil
+	})
+
+	//Canvas Blurred
+	p.addEventListener(p.canvas, "blur", func(this js.Value, args []js.Value) interface{} {
+		handler(Event{Type: EventBlur})
+		return nil
+	})
+
+	//Key Down
+	p.addEventListener(p.canvas, "keydown", func(this js.Value, args []js.Value) interface{} {
+		//Get the event and ditch repeated keys
+		evt := args[0]
+		if evt.Get("repeat").Bool() {
+			return nil
+		}
+
+		handler(Event{Type: EventKeyDown, Key: Key(evt.Get("keyCode").Int())})
+		return nil
+	})
+
+	//Key Up
+	p.addEventListener(p.canvas, "keyup", func(this js.Value, args []js.Value) interface{} {
+		evt := args[0]
+		handler(Event{Type: EventKeyUp, Key: Key(evt.Get("keyCode").Int())})
+		return nil
+	})
+}
+
+//addEventListener adds a listener to the target and keeps track of it so it can be released
+func (p *DOMPlatform) addEventListener(target js.Value, event string, fn func(this js.Value, args []js.Value) interface{}) {
+	jsfunc := js.FuncOf(fn)
+	target.Call("addEventListener", event, jsfunc)
+	p.listeners = append(p.listeners, domListener{target, event, jsfunc})
+}
+
+//Release removes all the listeners and cancels the pending frame
+func (p *DOMPlatform) Release() {
+	for _, listener := range p.listeners {
+		listener.target.Call("removeEventListener", listener.event, listener.fn)
+		listener.fn.Release()
+	}
+	p.listeners = nil
+	p.unwatchPixelRatio()
+
+	//Cancel the pending frame before releasing its function
+	if p.framePending {
+		js.Global().Call("cancelAnimationFrame", p.frameRequest)
+		p.framePending = false
+	}
+	p.frameCallback = nil
+	p.frameFunc.Release()
+}
@@ -0,0 +1,265 @@
+//Package primitive generates meshes of common shapes. The meshes have positions, normals, UVs, tangents and indices,
+// but are not uploaded, so they can be changed first and built without a GL context.
+//
+//Shapes are centered on the origin with Y up, and their triangles wind counter clockwise when seen from outside.
+// UVs start from the top left of the texture.
+package primitive
+
+import (
+	"math"
+
+	"github.com/lachee/noodle"
+)
+
+//vertex is a single point generated by a shape
+type vertex struct {
+	position noodle.Vector3
+	normal   noodle.Vector3
+	uv       noodle.Vector2
+}
+
+//grid adds a surface of (columns + 1) x (rows + 1) vertices to the mesh and joins them up with triangles.
+// Each triangle is wound so it faces the same way as the normals of its vertices.
+func grid(mesh *noodle.Mesh, columns, rows int, fn func(column, row int) vertex) {
+	start := uint32(len(mesh.Positions))
+	for row := 0; row <= rows; row++ {
+		for column := 0; column <= columns; column++ {
+			v := fn(column, row)
+			mesh.Positions = append(mesh.Positions, v.position)
+			mesh.Normals = append(mesh.Normals, v.normal)
+			mesh.UVs = append(mesh.UVs, v.uv)
+		}
+	}
+
+	stride := uint32(columns + 1)
+	for row := uint32(0); row < uint32(rows); row++ {
+		for column := uint32(0); column < uint32(columns); column++ {
+			a := start + row*stride + column
+			b := a + 1
+			c := a + stride
+			d := c + 1
+			triangle(mesh, a, c, d)
+			triangle(mesh, a, d, b)
+		}
+	}
+}
+
+//triangle adds the triangle to the mesh, flipping it if it faces away from its normals. Triangles with no area are skipped.
+func triangle(mesh *noodle.Mesh, a, b, c uint32) {
+	p := mesh.Positions
+	face := p[b].Subtract(p[a]).CrossProduct(p[c].Subtract(p[a]))
+	if face.SqrLength() == 0 {
+		return
+	}
+
+	normal := mesh.Normals[a].Add(mesh.Normals[b]).Add(mesh.Normals[c])
+	if face.DotProduct(normal) < 0 {
+		b, c = c, b
+	}
+	mesh.Indices = append(mesh.Indices, a, b, c)
+}
+
+//finish generates the tangents of the mesh. The shapes build their own indices, so an error is a bug in the shape.
+func finish(mesh *noodle.Mesh) *noodle.Mesh {
+	if err := mesh.GenerateTangents(); err != nil {
+		panic("primitive: " + err.Error())
+	}
+	return mesh
+}
+
+//atLeast makes sure a number of segments is not below the minimum
+func atLeast(segments, min int) int {
+	if segments < min {
+		return min
+	}
+	return segments
+}
+
+//sincos gets the sine and cosine of the angle in radians. Results within rounding error of 0 are made exactly 0, as
+// sin(π) is not, so the points at the poles all end up in the same place and their triangles with no area are skipped.
+func sincos(angle float64) (float32, float32) {
+	sin, cos := math.Sincos(angle)
+	return float32(snap(sin)), float32(snap(cos))
+}
+
+//snap makes a value that is only off 0 from rounding error exactly 0
+func snap(value float64) float64 {
+	if math.Abs(value) < 1e-12 {
+		return 0
+	}
+	return value
+}
+
+//Plane creates a flat surface on the XZ plane that faces up, split into segments along each side
+func Plane(width, depth float32, segmentsX, segmentsZ int) *noodle.Mesh {
+	segmentsX, segmentsZ = atLeast(segmentsX, 1), atLeast(segmentsZ, 1)
+	mesh := noodle.NewMesh()
+	grid(mesh, segmentsX, segmentsZ, func(column, row int) vertex {
+		u, v := float32(column)/float32(segmentsX), float32(row)/float32(segmentsZ)
+		return vertex{
+			position: noodle.Vector3{X: (u - 0.5) * width, Y: 0, Z: (v - 0.5) * depth},
+			normal:   noodle.Vector3{X: 0, Y: 1, Z: 0},
+			uv:       noodle.Vector2{X: u, Y: v},
+		}
+	})
+	return finish(mesh)
+}
+
+//Cube creates a cube with sides of the size. Each face is split into segments along each side.
+func Cube(size float32, segments int) *noodle.Mesh {
+	return Box(noodle.Vector3{X: size, Y: size, Z: size}, segments)
+}
+
+//Box creates a box of the size. Each face is split into segments along each side and has the whole texture.
+func Box(size noodle.Vector3, segments int) *noodle.Mesh {
+	segments = atLeast(segments, 1)
+	half := size.Scale(0.5)
+
+	//Each face is described by its normal and the directions that are right and up when looking at it
+	faces := [6]struct{ normal, right, up noodle.Vector3 }{
+		{noodle.Vector3{X: 0, Y: 0, Z: 1}, noodle.Vector3{X: 1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 1, Z: 0}},
+		{noodle.Vector3{X: 0, Y: 0, Z: -1}, noodle.Vector3{X: -1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 1, Z: 0}},
+		{noodle.Vector3{X: 1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 0, Z: -1}, noodle.Vector3{X: 0, Y: 1, Z: 0}},
+		{noodle.Vector3{X: -1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 0, Z: 1}, noodle.Vector3{X: 0, Y: 1, Z: 0}},
+		{noodle.Vector3{X: 0, Y: 1, Z: 0}, noodle.Vector3{X: 1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 0, Z: -1}},
+		{noodle.Vector3{X: 0, Y: -1, Z: 0}, noodle.Vector3{X: 1, Y: 0, Z: 0}, noodle.Vector3{X: 0, Y: 0, Z: 1}},
+	}
+
+	mesh := noodle.NewMesh()
+	for _, face := range faces {
+		face := face
+		center := face.normal.Multiply(half)
+		right := face.right.Multiply(half)
+		up := face.up.Multiply(half)
+
+		grid(mesh, segments, segments, func(column, row int) vertex {
+			u, v := float32(column)/float32(segments), float32(row)/float32(segments)
+			return vertex{
+				position: center.Add(right.Scale(u*2 - 1)).Add(up.Scale(1 - v*2)),
+				normal:   face.normal,
+				uv:       noodle.Vector2{X: u, Y: v},
+			}
+		})
+	}
+	return finish(mesh)
+}
+
+//Sphere creates a UV sphere with segments around its middle and rings from top to bottom
+func Sphere(radius float32, segments, rings int) *noodle.Mesh {
+	segments, rings = atLeast(segments, 3), atLeast(rings, 2)
+	mesh := noodle.NewMesh()
+	grid(mesh, segments, rings, func(column, row int) vertex {
+		u, v := float32(column)/float32(segments), float32(row)/float32(rings)
+		sinTheta, cosTheta := sincos(float64(u) * 2 * math.Pi)
+		sinPhi, cosPhi := sincos(float64(v) * math.Pi)
+
+		normal := noodle.Vector3{X: sinPhi * sinTheta, Y: cosPhi, Z: sinPhi * cosTheta}
+		return vertex{
+			position: normal.Scale(radius),
+			normal:   normal,
+			uv:       noodle.Vector2{X: u, Y: v},
+		}
+	})
+	return finish(mesh)
+}
+
+//Cylinder creates a capped cylinder standing along the Y axis, with segments around it and rings along its height
+func Cylinder(radius, height float32, segments, rings int) *noodle.Mesh {
+	segments, rings = atLeast(segments, 3), atLeast(rings, 1)
+	mesh := noodle.NewMesh()
+
+	//The side wraps the texture around once
+	grid(mesh, segments, rings, func(column, row int) vertex {
+		u, v := float32(column)/float32(segments), float32(row)/float32(rings)
+		sin, cos := sincos(float64(u) * 2 * math.Pi)
+		return vertex{
+			position: noodle.Vector3{X: sin * radius, Y: height/2 - v*height, Z: cos * radius},
+			normal:   noodle.Vector3{X: sin, Y: 0, Z: cos},
+			uv:       noodle.Vector2{X: u, Y: v},
+		}
+	})
+
+	disc(mesh, radius, height/2, 1, segments)
+	disc(mesh, radius, -height/2, -1, segments)
+	return finish(mesh)
+}
+
+//disc adds a disc at the height facing up or down. The texture is projected on from above.
+func disc(mesh *noodle.Mesh, radius, y, facing float32, segments int) {
+	grid(mesh, segments, 1, func(column, row int) vertex {
+		sin, cos := sincos(float64(column) / float64(segments) * 2 * math.Pi)
+		x, z := sin*radius*float32(row), cos*radius*float32(row)
+		return vertex{
+			position: noodle.Vector3{X: x, Y: y, Z: z},
+			normal:   noodle.Vector3{X: 0, Y: facing, Z: 0},
+			uv:       noodle.Vector2{X: 0.5 + x/(2*radius), Y: 0.5 + facing*z/(2*radius)},
+		}
+	})
+}
+
+//Torus creates a ring lying on the XZ plane. The radius is to the middle of the tube, with segments around the ring and sides around the tube.
+func Torus(radius, tubeRadius float32, segments, sides int) *noodle.Mesh {
+	segments, sides = atLeast(segments, 3), atLeast(sides, 3)
+	mesh := noodle.NewMesh()
+	grid(mesh, segments, sides, func(column, row int) vertex {
+		u, v := float32(column)/float32(segments), float32(row)/float32(sides)
+		sinTheta, cosTheta := sincos(float64(u) * 2 * math.Pi)
+		sinPhi, cosPhi := sincos(float64(v) * 2 * math.Pi)
+
+		normal := noodle.Vector3{X: cosPhi * sinTheta, Y: sinPhi, Z: cosPhi * cosTheta}
+		center := noodle.Vector3{X: sinTheta * radius, Y: 0, Z: cosTheta * radius}
+		return vertex{
+			position: center.Add(normal.Scale(tubeRadius)),
+			normal:   normal,
+			uv:       noodle.Vector2{X: u, Y: v},
+		}
+	})
+	return finish(mesh)
+}
+
+//Capsule creates a cylinder with rounded ends standing along the Y axis. The height includes the ends, and is never less than
+// the diameter. There are segments around it and rings in each of the rounded ends.
+func Capsule(radius, height float32, segments, rings int) *noodle.Mesh {
+	segments, rings = atLeast(segments, 3), atLeast(rings, 1)
+	half := height/2 - radius
+	if half < 0 {
+		half = 0
+	}
+
+	//The profile runs from the top down through both rounded ends. The last ring of the top end and the first of the
+	// bottom are both at the widest point, so the row between them is the straight side.
+	type ring struct{ y, sinPhi, cosPhi float32 }
+	profile := make([]ring, 0, rings*2+2)
+	for i := 0; i <= rings; i++ {
+		sin, cos := sincos(float64(i) / float64(rings) * math.Pi / 2)
+		profile = append(profile, ring{half + cos*radius, sin, cos})
+	}
+	for i := 0; i <= rings; i++ {
+		sin, cos := sincos(math.Pi/2 + float64(i)/float64(rings)*math.Pi/2)
+		profile = append(profile, ring{-half + cos*radius, sin, cos})
+	}
+
+	//The texture is stretched along the length of the profile so it is not squashed on the ends
+	lengths := make([]float32, len(profile))
+	for i := 1; i < len(profile); i++ {
+		dy := profile[i-1].y - profile[i].y
+		dr := (profile[i].sinPhi - profile[i-1].sinPhi) * radius
+		lengths[i] = lengths[i-1] + float32(math.Sqrt(float64(dy*dy+dr*dr)))
+	}
+	total := lengths[len(lengths)-1]
+
+	mesh := noodle.NewMesh()
+	grid(mesh, segments, len(profile)-1, func(column, row int) vertex {
+		u := float32(column) / float32(segments)
+		sinTheta, cosTheta := sincos(float64(u) * 2 * math.Pi)
+		r := profile[row]
+
+		normal := noodle.Vector3{X: r.sinPhi * sinTheta, Y: r.cosPhi, Z: r.sinPhi * cosTheta}
+		return vertex{
+			position: noodle.Vector3{X: normal.X * radius, Y: r.y, Z: normal.Z * radius},
+			normal:   normal,
+			uv:       noodle.Vector2{X: u, Y: lengths[row] / total},
+		}
+	})
+	return finish(mesh)
+}
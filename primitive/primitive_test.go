@@ -0,0 +1,64 @@
+package primitive
+
+import (
+	"testing"
+
+	"github.com/lachee/noodle"
+)
+
+//outward gets the direction a triangle at the point should face
+type outward func(point noodle.Vector3) noodle.Vector3
+
+//fromOrigin faces away from the center of the shape, which is right for the convex shapes
+func fromOrigin(point noodle.Vector3) noodle.Vector3 { return point }
+
+func TestShapes(t *testing.T) {
+	shapes := []struct {
+		name     string
+		mesh     *noodle.Mesh
+		vertices int
+		indices  int
+		outward  outward
+	}{
+		{"plane", Plane(2, 2, 3, 2), 12, 36, func(noodle.Vector3) noodle.Vector3 { return noodle.Vector3{X: 0, Y: 1, Z: 0} }},
+		{"cube", Cube(1, 2), 54, 144, fromOrigin},
+		{"sphere", Sphere(1, 8, 4), 45, 144, fromOrigin},
+		{"cylinder", Cylinder(1, 2, 8, 1), 54, 96, fromOrigin},
+		{"capsule", Capsule(0.5, 2, 8, 2), 54, 192, fromOrigin},
+		{"torus", Torus(1, 0.25, 8, 6), 63, 288, func(point noodle.Vector3) noodle.Vector3 {
+			//Away from the middle of the tube
+			ring := noodle.Vector3{X: point.X, Y: 0, Z: point.Z}.Normalize()
+			return point.Subtract(ring)
+		}},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			mesh := shape.mesh
+			if len(mesh.Positions) != shape.vertices || len(mesh.Indices) != shape.indices {
+				t.Fatalf("expected %d vertices and %d indices, got %d and %d", shape.vertices, shape.indices, len(mesh.Positions), len(mesh.Indices))
+			}
+			if len(mesh.Normals) != len(mesh.Positions) || len(mesh.UVs) != len(mesh.Positions) || len(mesh.Tangents) != len(mesh.Positions) {
+				t.Fatal("every vertex should have a normal, UV and tangent")
+			}
+
+			for i, normal := range mesh.Normals {
+				if length := normal.Length(); length < 0.9999 || length > 1.0001 {
+					t.Fatalf("normal %d has a length of %v", i, length)
+				}
+			}
+
+			for i := 0; i < len(mesh.Indices); i += 3 {
+				a, b, c := mesh.Positions[mesh.Indices[i]], mesh.Positions[mesh.Indices[i+1]], mesh.Positions[mesh.Indices[i+2]]
+				face := b.Subtract(a).CrossProduct(c.Subtract(a))
+				if face.SqrLength() == 0 {
+					t.Fatalf("triangle %d has no area", i/3)
+				}
+				centroid := a.Add(b).Add(c).Scale(1.0 / 3)
+				if face.DotProduct(shape.outward(centroid)) <= 0 {
+					t.Fatalf("triangle %d at %v winds inwards", i/3, centroid)
+				}
+			}
+		})
+	}
+}
@@ -213,37 +213,32 @@ func (q Quaternion) Nlerp(target Quaternion, amount float32) Quaternion {
 	return q.Lerp(target, amount).Normalize()
 }
 
-//Slerp Spherically Lerped
+//Slerp Spherically Lerped. It always takes the shortest path between the rotations, and uses Nlerp for rotations that are
+// almost the same. Before the gltf package it never moved away from q.
 func (q Quaternion) Slerp(q2 Quaternion, amount float32) Quaternion {
 	cosHalfTheta := q.X*q2.X + q.Y*q2.Y + q.Z*q2.Z + q.W*q2.W
-	if math.Abs((float64(cosHalfTheta))) >= 1 {
-		return q
+	if cosHalfTheta < 0 {
+		q2 = q2.Scale(-1)
+		cosHalfTheta = -cosHalfTheta
 	}
 
-	if cosHalfTheta > 0.95 {
+	//The ratios divide by sinHalfTheta, so rotations that are almost the same are lerped instead.
+	// Nlerp is close enough for them, and does not snap tiny rotations back to q.
+	sinHalfTheta := float32(math.Sqrt(math.Max(0, float64(1-cosHalfTheta*cosHalfTheta))))
+	if cosHalfTheta > 0.95 || sinHalfTheta < 0.001 {
 		return q.Nlerp(q2, amount)
 	}
 
 	halfTheta := float32(math.Acos(float64(cosHalfTheta)))
-	sinHalfTheta := float32(math.Sqrt(float64(1 - cosHalfTheta*cosHalfTheta)))
-
-	if math.Abs(float64(sinHalfTheta)) < 0.001 {
-		return Quaternion{
-			X: q.X*0.5 + q.X*0.5,
-			Y: q.Y*0.5 + q.Y*0.5,
-			Z: q.Z*0.5 + q.Z*0.5,
-			W: q.W*0.5 + q.W*0.5,
-		}
-	}
 
 	ratioA := float32(math.Sin(float64((1-amount)*halfTheta)) / float64(sinHalfTheta))
 	ratioB := float32(math.Sin(float64(amount*halfTheta)) / float64(sinHalfTheta))
 
 	return Quaternion{
-		X: q.X*ratioA + q.X*ratioB,
-		Y: q.Y*ratioA + q.Y*ratioB,
-		Z: q.Z*ratioA + q.Z*ratioB,
-		W: q.W*ratioA + q.W*ratioB,
+		X: q.X*ratioA + q2.X*ratioB,
+		Y: q.Y*ratioA + q2.Y*ratioB,
+		Z: q.Z*ratioA + q2.Z*ratioB,
+		W: q.W*ratioA + q2.W*ratioB,
 	}
 }
 
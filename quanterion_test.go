@@ -0,0 +1,46 @@
+package noodle
+
+import (
+	"math"
+	"testing"
+)
+
+//quaternionNear checks if the quaternions are within the tolerance
+func quaternionNear(a, b Quaternion, tolerance float32) bool {
+	return Abs32(a.X-b.X) <= tolerance && Abs32(a.Y-b.Y) <= tolerance && Abs32(a.Z-b.Z) <= tolerance && Abs32(a.W-b.W) <= tolerance
+}
+
+//yaw is a rotation of the angle about Y
+func yaw(angle float64) Quaternion {
+	return Quaternion{Y: float32(math.Sin(angle / 2)), W: float32(math.Cos(angle / 2))}
+}
+
+//Slerp used to blend the first rotation with itself, so it never moved towards the second
+func TestQuaternionSlerp(t *testing.T) {
+	from, to := NewQuaternionIdentity(), yaw(math.Pi/2)
+	amounts := []float32{0, 0.25, 0.5, 1}
+	for _, amount := range amounts {
+		expected := yaw(math.Pi / 2 * float64(amount))
+		if result := from.Slerp(to, amount); !quaternionNear(result, expected, 1e-5) {
+			t.Errorf("%v of the way: expected %v, got %v", amount, expected, result)
+		}
+	}
+}
+
+func TestQuaternionSlerpShortestPath(t *testing.T) {
+	//The negated quaternion is the same rotation, so going halfway to it should take the short way round
+	from, to := NewQuaternionIdentity(), yaw(math.Pi/2).Scale(-1)
+	if result := from.Slerp(to, 0.5); !quaternionNear(result, yaw(math.Pi/4), 1e-5) {
+		t.Errorf("expected %v, got %v", yaw(math.Pi/4), result)
+	}
+}
+
+func TestQuaternionSlerpSmallAngles(t *testing.T) {
+	from := NewQuaternionIdentity()
+	for _, angle := range []float64{1e-2, 1e-4, 1e-7} {
+		result := from.Slerp(yaw(angle), 0.5)
+		if math.IsNaN(float64(result.W)) || !quaternionNear(result, yaw(angle/2), 1e-5) || Abs32(result.Length()-1) > 1e-5 {
+			t.Errorf("%v radians: expected %v, got %v", angle, yaw(angle/2), result)
+		}
+	}
+}
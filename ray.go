@@ -0,0 +1,179 @@
+package noodle
+
+import "math"
+
+//Ray is a line that starts at an origin and goes on forever in one direction
+type Ray struct {
+	Origin    Vector3
+	Direction Vector3
+}
+
+//NewRay creates a new ray. The direction is normalized.
+func NewRay(origin, direction Vector3) Ray {
+	return Ray{Origin: origin, Direction: direction.Normalize()}
+}
+
+//Point gets the point that is the distance along the ray
+func (r Ray) Point(distance float32) Vector3 {
+	return r.Origin.Add(r.Direction.Scale(distance))
+}
+
+//IntersectPlane gets the distance along the ray where it hits the plane. Returns false if the ray is parallel to the plane or points away from it.
+func (r Ray) IntersectPlane(plane Plane) (float32, bool) {
+	denom := plane.Normal.DotProduct(r.Direction)
+	if denom == 0 {
+		return 0, false
+	}
+
+	distance := -plane.DistanceToPoint(r.Origin) / denom
+	return distance, distance >= 0
+}
+
+//IntersectSphere gets the distance along the ray where it first hits the sphere. If the ray starts inside the sphere, the distance is 0.
+func (r Ray) IntersectSphere(center Vector3, radius float32) (float32, bool) {
+	offset := r.Origin.Subtract(center)
+	b := offset.DotProduct(r.Direction)
+	c := offset.DotProduct(offset) - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	if b > 0 {
+		return 0, false
+	}
+
+	discriminant := b*b - c
+	if discriminant < 0 {
+		return 0, false
+	}
+	return -b - float32(math.Sqrt(float64(discriminant))), true
+}
+
+//IntersectBox gets the distance along the ray where it first hits the axis aligned box. If the ray starts inside the box, the distance is 0.
+func (r Ray) IntersectBox(min, max Vector3) (float32, bool) {
+	near, far := float32(0), Inf(1)
+	origin, direction := r.Origin.Decompose(), r.Direction.Decompose()
+	lower, upper := min.Decompose(), max.Decompose()
+
+	for axis := 0; axis < 3; axis++ {
+		if direction[axis] == 0 {
+			if origin[axis] < lower[axis] || origin[axis] > upper[axis] {
+				return 0, false
+			}
+			continue
+		}
+
+		t1 := (lower[axis] - origin[axis]) / direction[axis]
+		t2 := (upper[axis] - origin[axis]) / direction[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > near {
+			near = t1
+		}
+		if t2 < far {
+			far = t2
+		}
+		if near > far {
+			return 0, false
+		}
+	}
+
+	return near, true
+}
+
+//Plane is an infinite flat surface. Points on the plane satisfy Normal.DotProduct(point) + Distance == 0.
+type Plane struct {
+	Normal   Vector3
+	Distance float32
+}
+
+//NewPlane creates a plane with the normal that passes through the point
+func NewPlane(normal, point Vector3) Plane {
+	normal = normal.Normalize()
+	return Plane{Normal: normal, Distance: -normal.DotProduct(point)}
+}
+
+//Normalize scales the plane so its normal has a length of 1
+func (p Plane) Normalize() Plane {
+	length := p.Normal.Length()
+	if length == 0 {
+		return p
+	}
+	return Plane{Normal: p.Normal.Divide(length), Distance: p.Distance / length}
+}
+
+//DistanceToPoint gets the signed distance from the plane to the point. It is positive on the side the normal faces.
+func (p Plane) DistanceToPoint(point Vector3) float32 {
+	return p.Normal.DotProduct(point) + p.Distance
+}
+
+//Frustum is the six planes that surround what a camera can see. The normals of the planes face inwards.
+type Frustum struct {
+	Left, Right, Bottom, Top, Near, Far Plane
+}
+
+//NewFrustumMatrix extracts the planes from a view projection matrix
+func NewFrustumMatrix(m Matrix) Frustum {
+	row := func(x, y, z, w float32) Plane { return Plane{Vector3{x, y, z}, w} }
+	r0 := row(m.M0, m.M4, m.M8, m.M12)
+	r1 := row(m.M1, m.M5, m.M9, m.M13)
+	r2 := row(m.M2, m.M6, m.M10, m.M14)
+	r3 := row(m.M3, m.M7, m.M11, m.M15)
+
+	add := func(a, b Plane) Plane { return Plane{a.Normal.Add(b.Normal), a.Distance + b.Distance}.Normalize() }
+	sub := func(a, b Plane) Plane { return Plane{a.Normal.Subtract(b.Normal), a.Distance - b.Distance}.Normalize() }
+	return Frustum{
+		Left:   add(r3, r0),
+		Right:  sub(r3, r0),
+		Bottom: add(r3, r1),
+		Top:    sub(r3, r1),
+		Near:   add(r3, r2),
+		Far:    sub(r3, r2),
+	}
+}
+
+//Planes gets the six planes as a slice
+func (f Frustum) Planes() []Plane {
+	return []Plane{f.Left, f.Right, f.Bottom, f.Top, f.Near, f.Far}
+}
+
+//ContainsPoint checks if the point is inside the frustum
+func (f Frustum) ContainsPoint(point Vector3) bool {
+	for _, plane := range f.Planes() {
+		if plane.DistanceToPoint(point) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//IntersectsSphere checks if any part of the sphere is inside the frustum
+func (f Frustum) IntersectsSphere(center Vector3, radius float32) bool {
+	for _, plane := range f.Planes() {
+		if plane.DistanceToPoint(center) < -radius {
+			return false
+		}
+	}
+	return true
+}
+
+//IntersectsBox checks if any part of the axis aligned box is inside the frustum
+func (f Frustum) IntersectsBox(min, max Vector3) bool {
+	for _, plane := range f.Planes() {
+		//Test the corner that is furthest along the normal
+		corner := min
+		if plane.Normal.X >= 0 {
+			corner.X = max.X
+		}
+		if plane.Normal.Y >= 0 {
+			corner.Y = max.Y
+		}
+		if plane.Normal.Z >= 0 {
+			corner.Z = max.Z
+		}
+		if plane.DistanceToPoint(corner) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package noodle
+
+import "testing"
+
+func TestRayIntersectPlane(t *testing.T) {
+	ground := NewPlane(Vector3{0, 1, 0}, Vector3{0, -2, 0})
+	ray := NewRay(Vector3{0, 3, 0}, Vector3{1, -1, 0})
+
+	distance, ok := ray.IntersectPlane(ground)
+	if !ok || !vectorNear(ray.Point(distance), Vector3{5, -2, 0}, 1e-4) {
+		t.Errorf("expected the ray to hit at (5, -2, 0), got %v at %v", ok, ray.Point(distance))
+	}
+	if _, ok := NewRay(Vector3{0, 3, 0}, Vector3{1, 0, 0}).IntersectPlane(ground); ok {
+		t.Error("a parallel ray hit the plane")
+	}
+	if _, ok := NewRay(Vector3{0, 3, 0}, Vector3{0, 1, 0}).IntersectPlane(ground); ok {
+		t.Error("a ray pointing away hit the plane")
+	}
+}
+
+func TestRayIntersectSphere(t *testing.T) {
+	center := Vector3{0, 0, -10}
+	distance, ok := NewRay(Vector3{}, Vector3{0, 0, -1}).IntersectSphere(center, 2)
+	if !ok || Abs32(distance-8) > 1e-5 {
+		t.Errorf("expected the ray to hit the front of the sphere at 8, got %v %v", distance, ok)
+	}
+
+	//A ray that grazes past, a ray that points away and a ray that starts inside
+	if _, ok := NewRay(Vector3{3, 0, 0}, Vector3{0, 0, -1}).IntersectSphere(center, 2); ok {
+		t.Error("a ray beside the sphere hit it")
+	}
+	if _, ok := NewRay(Vector3{}, Vector3{0, 0, 1}).IntersectSphere(center, 2); ok {
+		t.Error("a ray pointing away hit the sphere")
+	}
+	if distance, ok := NewRay(Vector3{0, 0, -9}, Vector3{0, 0, 1}).IntersectSphere(center, 2); !ok || distance != 0 {
+		t.Errorf("expected a ray inside the sphere to hit at 0, got %v %v", distance, ok)
+	}
+}
+
+func TestRayIntersectBox(t *testing.T) {
+	min, max := Vector3{-1, -1, -1}, Vector3{1, 1, 1}
+	distance, ok := NewRay(Vector3{-5, 0.5, 0}, Vector3{1, 0, 0}).IntersectBox(min, max)
+	if !ok || Abs32(distance-4) > 1e-5 {
+		t.Errorf("expected the ray to hit the side of the box at 4, got %v %v", distance, ok)
+	}
+	if _, ok := NewRay(Vector3{-5, 2, 0}, Vector3{1, 0, 0}).IntersectBox(min, max); ok {
+		t.Error("a ray above the box hit it")
+	}
+	if distance, ok := NewRay(Vector3{}, Vector3{0, 1, 0}).IntersectBox(min, max); !ok || distance != 0 {
+		t.Errorf("expected a ray inside the box to hit at 0, got %v %v", distance, ok)
+	}
+}
@@ -1,5 +1,22 @@
 package noodle
 
+//RendererOptions changes how a built-in renderer is created. The zero value uses the shader built into noodle.
+type RendererOptions struct {
+	//Shader replaces the built-in shader. It needs the same attributes and uniforms as the built-in one, and the renderer
+	// does not release it.
+	Shader *Shader
+}
+
+//rendererShader gets the shader of the options, or compiles the built-in one. owned is true if the renderer compiled it.
+func (options RendererOptions) rendererShader(vertCode, fragCode string) (shader *Shader, owned bool, err error) {
+	if options.Shader != nil {
+		return options.Shader, false, nil
+	}
+
+	shader, err = LoadShader(vertCode, fragCode)
+	return shader, err == nil, err
+}
+
 /*
 type Renderer interface {
 	Setup()
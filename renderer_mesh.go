@@ -0,0 +1,53 @@
+package noodle
+
+//MeshRenderer draws meshes with materials from the point of view of a camera
+type MeshRenderer struct {
+	view       Matrix
+	projection Matrix
+	drawing    bool
+}
+
+//NewMeshRenderer creates a new mesh renderer
+func NewMeshRenderer() *MeshRenderer {
+	return &MeshRenderer{}
+}
+
+//Begin starts drawing from the point of view of the camera, turning on the depth test
+func (r *MeshRenderer) Begin(camera Camera) error {
+	if r.drawing {
+		return fail(ErrAlreadyDrawing)
+	}
+
+	r.drawing = true
+	r.view = camera.ViewMatrix()
+	r.projection = camera.ProjectionMatrix()
+
+	GL.Enable(GlDepthTest)
+	GL.DepthFunc(GlLEqual)
+	return nil
+}
+
+//Draw draws the mesh with the material. The model matrix places it in the world, such as Node.WorldMatrix().
+func (r *MeshRenderer) Draw(mesh *Mesh, material *Material, model Matrix) error {
+	if !r.drawing {
+		return fail(ErrNotDrawing)
+	}
+
+	material.Use()
+	shader := material.Shader
+	GL.UniformMatrix4fv(shader.GetUniformLocation(UniformModel), model)
+	GL.UniformMatrix4fv(shader.GetUniformLocation(UniformView), r.view)
+	GL.UniformMatrix4fv(shader.GetUniformLocation(UniformProjection), r.projection)
+	mesh.Draw(shader)
+	return nil
+}
+
+//End finishes drawing
+func (r *MeshRenderer) End() error {
+	if !r.drawing {
+		return fail(ErrNotDrawing)
+	}
+
+	r.drawing = false
+	return nil
+}
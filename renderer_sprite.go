@@ -1,9 +1,6 @@
 package noodle
 
-import (
-	"log"
-	"math"
-)
+import "math"
 
 //Based Heavily from https://github.com/ajhager/engi/blob/master/b.go
 
@@ -11,48 +8,40 @@ const batchMaxSize = 10000
 
 //SpriteRenderer renders UVTiles in a batched manner
 type SpriteRenderer struct {
-	Zoom   float32 //Zoom is the perspective zoom on the sprites
-	Camera Vector2 //Camera is the position of the camera
-
-	shader       *Shader
-	inPosition   int
-	inColor      int
-	inTexCoords  int
-	ufProjection WebGLUniformLocation
-	ufCamera     WebGLUniformLocation
+	shader      *Shader
+	ownsShader  bool
+	inPosition  int
+	inColor     int
+	inTexCoords int
+	ufMatrix    WebGLUniformLocation
 
 	vertices     []float32
 	indices      []uint16
 	vertexBuffer WebGLBuffer
 	indexBuffer  WebGLBuffer
+	context      *Context //context is the context the buffers were created in
 
 	drawing     bool
 	lastTexture *Texture
 	index       int
 }
 
-//NewSpriteRenderer creates a new sprite renderer
-func NewSpriteRenderer() *SpriteRenderer {
-	b := &SpriteRenderer{}
+//NewSpriteRenderer creates a new sprite renderer with the built-in shader
+func NewSpriteRenderer() (*SpriteRenderer, error) {
+	return NewSpriteRendererWithOptions(RendererOptions{})
+}
 
-	//setup the zoom
-	b.Zoom = 2.0
-	b.Camera = Vector2{}
+//NewSpriteRendererWithOptions creates a new sprite renderer, which can use its own shader
+func NewSpriteRendererWithOptions(options RendererOptions) (*SpriteRenderer, error) {
+	b := &SpriteRenderer{}
 
 	//Prepare the shader
-	var shaderError error
-	b.shader, shaderError = LoadShader(spriteRendererVertCode, spriteRendererFragCode)
-	if shaderError != nil {
-		log.Fatalln("Failed to compile batch shader!", shaderError)
-		return nil
+	var err error
+	b.shader, b.ownsShader, err = options.rendererShader(spriteRendererVertCode, spriteRendererFragCode)
+	if err != nil {
+		return nil, err
 	}
 
-	b.inPosition = b.shader.GetAttribLocation("in_Position")
-	b.inColor = b.shader.GetAttribLocation("in_Color")
-	b.inTexCoords = b.shader.GetAttribLocation("in_TexCoords")
-	b.ufProjection = b.shader.GetUniformLocation("uf_Projection")
-	b.ufCamera = b.shader.GetUniformLocation("uf_Camera")
-
 	//Prepare the verticies
 	b.vertices = make([]float32, 20*batchMaxSize)
 	b.indices = make([]uint16, 6*batchMaxSize)
@@ -68,10 +57,32 @@ func NewSpriteRenderer() *SpriteRenderer {
 	}
 
 	//Create the buffers
+	b.Restore()
+	b.context = track(b)
+	return b, nil
+}
+
+//Restore queries the shader locations and creates the buffers. It is called again after the context has been lost, once the shader has been restored.
+func (b *SpriteRenderer) Restore() error {
+	b.inPosition = b.shader.GetAttribLocation("in_Position")
+	b.inColor = b.shader.GetAttribLocation("in_Color")
+	b.inTexCoords = b.shader.GetAttribLocation("in_TexCoords")
+	b.ufMatrix = b.shader.GetUniformLocation("uf_Matrix")
+
 	b.indexBuffer = GL.CreateBuffer()
 	b.vertexBuffer = GL.CreateBuffer()
 	b.setupBuffers()
-	return b
+	return nil
+}
+
+//Release deletes the buffers of the renderer, and its shader if it is the built-in one
+func (b *SpriteRenderer) Release() {
+	b.context.gl.DeleteBuffer(b.indexBuffer)
+	b.context.gl.DeleteBuffer(b.vertexBuffer)
+	if b.ownsShader {
+		b.shader.Release()
+	}
+	b.context.Untrack(b)
 }
 
 func (b *SpriteRenderer) setupBuffers() {
@@ -92,10 +103,10 @@ func (b *SpriteRenderer) setupBuffers() {
 	GL.VertexAttribPointer(b.inColor, 4, GlUnsignedByte, true, 20, 16)
 }
 
-//Begin starts a SpriteRenderer
-func (b *SpriteRenderer) Begin() *SpriteRenderer {
+//Begin starts a SpriteRenderer. The matrix transforms the sprites into clip space, such as Camera2D.Matrix(), or ScreenMatrix() to draw in screen pixels.
+func (b *SpriteRenderer) Begin(matrix Matrix) error {
 	if b.drawing {
-		log.Fatal("b.End() must be called first")
+		return fail(ErrAlreadyDrawing)
 	}
 
 	b.drawing = true
@@ -105,20 +116,15 @@ func (b *SpriteRenderer) Begin() *SpriteRenderer {
 	GL.Enable(GlBlend)
 	GL.BlendFunc(GlSrcColor, GlOneMinusSrcAlpha)
 
-	//Set the projection X and Y
-	projX := float32(Width()) / b.Zoom
-	projY := float32(Height()) / b.Zoom
-	GL.Uniform2f(b.ufProjection, projX, projY)
-
 	//Set the camera
-	GL.Uniform2v(b.ufCamera, b.Camera)
-	return b
+	GL.UniformMatrix4fv(b.ufMatrix, matrix)
+	return nil
 }
 
 //End finalises a SpriteRenderer
-func (b *SpriteRenderer) End() *SpriteRenderer {
+func (b *SpriteRenderer) End() error {
 	if !b.drawing {
-		log.Fatal("b.Begin() must be called first")
+		return fail(ErrNotDrawing)
 	}
 
 	if b.index > 0 {
@@ -127,7 +133,7 @@ func (b *SpriteRenderer) End() *SpriteRenderer {
 
 	b.drawing = false
 	//b.lastTexture = nil
-	return b
+	return nil
 }
 
 //flush pushes the texture to GL
@@ -161,9 +167,9 @@ func (b *SpriteRenderer) flush() {
 }
 
 //Draw a particular texture
-func (b *SpriteRenderer) Draw(r UVTile, origin Vector2, transform Transform2D, color Color) {
+func (b *SpriteRenderer) Draw(r UVTile, origin Vector2, transform Transform2D, color Color) error {
 	if !b.drawing {
-		log.Fatal("Batch.Begin() must be called first")
+		return fail(ErrNotDrawing)
 	}
 
 	if r.Texture() != b.lastTexture {
@@ -305,18 +311,17 @@ func (b *SpriteRenderer) Draw(r UVTile, origin Vector2, transform Transform2D, c
 	if b.index >= batchMaxSize {
 		b.flush()
 	}
+	return nil
 }
 
 var spriteRendererVertCode = `
 attribute vec2 in_Position;
 attribute vec4 in_Color;
 attribute vec2 in_TexCoords;
-uniform vec2 uf_Projection;
-uniform vec2 uf_Camera;
+uniform mat4 uf_Matrix;
 varying vec4 var_Color;
 varying vec2 var_TexCoords;
-const vec2 center = vec2(-1.0, 1.0);
-void main() { var_Color = in_Color; var_TexCoords = in_TexCoords;	gl_Position = vec4(in_Position.x / uf_Projection.x + center.x, in_Position.y / -uf_Projection.y + center.y,  0.0, 1.0) + vec4(uf_Camera.x, uf_Camera.y, 0, 0); }`
+void main() { var_Color = in_Color; var_TexCoords = in_TexCoords; gl_Position = uf_Matrix * vec4(in_Position, 0.0, 1.0); }`
 
 var spriteRendererFragCode = `
 precision mediump float;
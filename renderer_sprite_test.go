@@ -0,0 +1,141 @@
+package noodle
+
+import (
+	"encoding/binary"
+	"image"
+	"math"
+	"testing"
+)
+
+//newTestTexture creates a blank texture of the size
+func newTestTexture(t *testing.T, width, height int) *Texture {
+	t.Helper()
+	img, err := LoadImageRGBA(image.NewRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img.CreateTexture()
+}
+
+//renderSprites draws a single frame with a new sprite renderer, returning the platform it was drawn on
+func renderSprites(t *testing.T, draw func(renderer *SpriteRenderer)) *HeadlessPlatform {
+	t.Helper()
+	app := &headlessApp{}
+	platform := startHeadless(t, app)
+	renderer, err := NewSpriteRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.render = func() {
+		if err := renderer.Begin(ScreenMatrix()); err != nil {
+			t.Fatal(err)
+		}
+		draw(renderer)
+		if err := renderer.End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !platform.Step(16) {
+		t.Fatal("no frame was requested")
+	}
+	return platform
+}
+
+//vertexFloat reads the float at the index from vertex data that was uploaded
+func vertexFloat(data []byte, index int) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(data[index*4:]))
+}
+
+func TestSpriteRendererBatchesByTexture(t *testing.T) {
+	first := newTestTexture(t, 4, 4)
+	second := newTestTexture(t, 8, 8)
+	draws := []*Texture{first, first, first, second, first, first}
+
+	platform := renderSprites(t, func(renderer *SpriteRenderer) {
+		for _, texture := range draws {
+			if err := renderer.Draw(texture, Vector2{}, NewTransform2D(Vector2{}, 0, Vector2{1, 1}), White); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	expected := []struct {
+		texture *Texture
+		sprites int
+	}{{first, 3}, {second, 1}, {first, 2}}
+
+	calls := platform.GL().DrawCalls()
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d draw calls, got %d", len(expected), len(calls))
+	}
+	for i, call := range calls {
+		if call.Mode != GlTriangles || call.ValueType != GlUnsignedShort || call.Count != expected[i].sprites*6 {
+			t.Errorf("draw %d: unexpected %+v", i, call)
+		}
+		if call.Texture != expected[i].texture.Data() {
+			t.Errorf("draw %d: drew the wrong texture", i)
+		}
+	}
+}
+
+func TestSpriteRendererUploadsEachBatch(t *testing.T) {
+	small := newTestTexture(t, 4, 4)
+	large := newTestTexture(t, 8, 8)
+
+	platform := renderSprites(t, func(renderer *SpriteRenderer) {
+		renderer.Draw(small, Vector2{}, NewTransform2D(Vector2{10, 20}, 0, Vector2{1, 1}), White)
+		renderer.Draw(large, Vector2{}, NewTransform2D(Vector2{30, 40}, 0, Vector2{2, 2}), White)
+	})
+
+	//Each batch uploads its own vertices, even though the renderer reuses the slice
+	uploads := callsNamed(platform.GL(), "bufferSubData")
+	if len(uploads) != 2 {
+		t.Fatalf("expected 2 uploads, got %d", len(uploads))
+	}
+	corners := [][4]float32{{10, 20, 14, 24}, {30, 40, 46, 56}}
+	for i, upload := range uploads {
+		data := upload.Args[2].([]byte)
+		//The first vertex is the top left, the third is the bottom right
+		got := [4]float32{vertexFloat(data, 0), vertexFloat(data, 1), vertexFloat(data, 10), vertexFloat(data, 11)}
+		if got != corners[i] {
+			t.Errorf("batch %d: expected the corners %v, got %v", i, corners[i], got)
+		}
+	}
+}
+
+func TestSpriteRendererFlushesFullBatch(t *testing.T) {
+	texture := newTestTexture(t, 1, 1)
+	platform := renderSprites(t, func(renderer *SpriteRenderer) {
+		for i := 0; i < batchMaxSize+1; i++ {
+			renderer.Draw(texture, Vector2{}, NewTransform2D(Vector2{}, 0, Vector2{1, 1}), White)
+		}
+	})
+
+	calls := platform.GL().DrawCalls()
+	if len(calls) != 2 || calls[0].Count != batchMaxSize*6 || calls[1].Count != 6 {
+		t.Fatalf("expected a full batch then a single sprite, got %+v", calls)
+	}
+}
+
+func TestSpriteRendererRequiresBegin(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	renderer, err := NewSpriteRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	texture := newTestTexture(t, 1, 1)
+
+	if err := renderer.Draw(texture, Vector2{}, NewTransform2D(Vector2{}, 0, Vector2{1, 1}), White); err != ErrNotDrawing {
+		t.Errorf("Draw before Begin returned %v", err)
+	}
+	if err := renderer.End(); err != ErrNotDrawing {
+		t.Errorf("End before Begin returned %v", err)
+	}
+	if err := renderer.Begin(ScreenMatrix()); err != nil {
+		t.Fatal(err)
+	}
+	if err := renderer.Begin(ScreenMatrix()); err != ErrAlreadyDrawing {
+		t.Errorf("Begin while drawing returned %v", err)
+	}
+}
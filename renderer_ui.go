@@ -1,9 +1,6 @@
 package noodle
 
-import (
-	"log"
-	"math"
-)
+import "math"
 
 //uiRendererVertexLength is how many bytes are in each "vertex" element.
 const uiRendererVertexLength = 44
@@ -14,7 +11,8 @@ type UIRenderer struct {
 	scale      float32
 	scaleInput bool
 
-	shader *Shader
+	shader     *Shader
+	ownsShader bool
 
 	inPosition    WebGLAttributeLocation
 	inTexCoords   WebGLAttributeLocation
@@ -30,6 +28,7 @@ type UIRenderer struct {
 	indices      []uint16
 	vertexBuffer WebGLBuffer
 	indexBuffer  WebGLBuffer
+	context      *Context //context is the context the buffers were created in
 
 	drawing bool
 	index   int
@@ -38,8 +37,13 @@ type UIRenderer struct {
 	texture *Texture
 }
 
-//NewUIRenderer creates a new sprite renderer
-func NewUIRenderer() *UIRenderer {
+//NewUIRenderer creates a new UI renderer with the built-in shader
+func NewUIRenderer() (*UIRenderer, error) {
+	return NewUIRendererWithOptions(RendererOptions{})
+}
+
+//NewUIRendererWithOptions creates a new UI renderer, which can use its own shader
+func NewUIRendererWithOptions(options RendererOptions) (*UIRenderer, error) {
 	b := &UIRenderer{}
 
 	b.Zoom = 2.0
@@ -47,23 +51,12 @@ func NewUIRenderer() *UIRenderer {
 	b.scaleInput = false
 
 	//Prepare the shader
-	var shaderError error
-	b.shader, shaderError = LoadShaderFromURL("resources/shader/ui.vert", "resources/shader/ui.frag")
-	if shaderError != nil {
-		log.Fatalln("Failed to compile batch shader!", shaderError)
-		return nil
+	var err error
+	b.shader, b.ownsShader, err = options.rendererShader(uiRendererVertCode, uiRendererFragCode)
+	if err != nil {
+		return nil, err
 	}
 
-	b.inPosition = b.shader.GetAttribLocation("position")
-	b.inTexCoords = b.shader.GetAttribLocation("texcoords")
-	b.inSliceCoords = b.shader.GetAttribLocation("slicecoords")
-	b.inDimension = b.shader.GetAttribLocation("dimension")
-	b.inColor = b.shader.GetAttribLocation("color")
-
-	b.uProjection = b.shader.GetUniformLocation("uProjection")
-	b.uSampler = b.shader.GetUniformLocation("uSampler")
-	b.uBorder = b.shader.GetUniformLocation("uBorder")
-
 	//Prepare the verticies
 	b.vertices = make([]float32, uiRendererVertexLength*batchMaxSize)
 	b.indices = make([]uint16, 6*batchMaxSize)
@@ -80,12 +73,37 @@ func NewUIRenderer() *UIRenderer {
 	}
 
 	//Create the buffers
+	b.Restore()
+	b.context = track(b)
+	return b, nil
+}
+
+//Restore queries the shader locations and creates the buffers. It is called again after the context has been lost, once the shader has been restored.
+func (b *UIRenderer) Restore() error {
+	b.inPosition = b.shader.GetAttribLocation("position")
+	b.inTexCoords = b.shader.GetAttribLocation("texcoords")
+	b.inSliceCoords = b.shader.GetAttribLocation("slicecoords")
+	b.inDimension = b.shader.GetAttribLocation("dimension")
+	b.inColor = b.shader.GetAttribLocation("color")
+
+	b.uProjection = b.shader.GetUniformLocation("uProjection")
+	b.uSampler = b.shader.GetUniformLocation("uSampler")
+	b.uBorder = b.shader.GetUniformLocation("uBorder")
 
 	b.indexBuffer = GL.CreateBuffer()
 	b.vertexBuffer = GL.CreateBuffer()
-
 	b.setupBuffers()
-	return b
+	return nil
+}
+
+//Release deletes the buffers of the renderer, and its shader if it is the built-in one
+func (b *UIRenderer) Release() {
+	b.context.gl.DeleteBuffer(b.indexBuffer)
+	b.context.gl.DeleteBuffer(b.vertexBuffer)
+	if b.ownsShader {
+		b.shader.Release()
+	}
+	b.context.Untrack(b)
 }
 
 func (b *UIRenderer) setupBuffers() {
@@ -136,9 +154,9 @@ func (b *UIRenderer) ScaleInput(state bool) {
 }
 
 //Begin starts a UIRenderer
-func (b *UIRenderer) Begin() *UIRenderer {
+func (b *UIRenderer) Begin() error {
 	if b.drawing {
-		log.Fatal("b.End() must be called first")
+		return fail(ErrAlreadyDrawing)
 	}
 
 	b.drawing = true
@@ -156,13 +174,13 @@ func (b *UIRenderer) Begin() *UIRenderer {
 	//Clear the cache
 	b.texture = nil
 	b.sprite = nil
-	return b
+	return nil
 }
 
 //End finalises a UIRenderer
-func (b *UIRenderer) End() *UIRenderer {
+func (b *UIRenderer) End() error {
 	if !b.drawing {
-		log.Fatal("b.Begin() must be called first")
+		return fail(ErrNotDrawing)
 	}
 
 	if b.index > 0 {
@@ -170,7 +188,7 @@ func (b *UIRenderer) End() *UIRenderer {
 	}
 
 	b.drawing = false
-	return b
+	return nil
 }
 
 //flush pushes the texture to GL
@@ -210,25 +228,33 @@ func (b *UIRenderer) flush() {
 	b.index = 0
 }
 
-//SetSprite sets teh current sprite
-func (b *UIRenderer) SetSprite(sprite *SliceSprite) {
+//SetSprite sets the sprite the following rectangles are drawn with. It is cleared by Begin.
+func (b *UIRenderer) SetSprite(sprite *SliceSprite) error {
+	if !b.drawing {
+		return fail(ErrNotDrawing)
+	}
+	if sprite == nil {
+		return fail(ErrNoSprite)
+	}
+
 	//Flush previous sprites
-	if sprite != b.sprite && b.sprite != nil {
+	if sprite != b.sprite && b.index > 0 {
 		b.flush()
 	}
 
 	//Setup the new sprite
 	b.sprite = sprite
+	return nil
 }
 
 //Draw a particular texture
-func (b *UIRenderer) Draw(rect Rectangle, color Color) {
+func (b *UIRenderer) Draw(rect Rectangle, color Color) error {
 	if !b.drawing {
-		log.Fatal("Batch.Begin() must be called first")
+		return fail(ErrNotDrawing)
 	}
 
 	if b.sprite == nil {
-		log.Fatal("Renderer.SetSprite must be called first")
+		return fail(ErrNoSprite)
 	}
 
 	if b.scaleInput {
@@ -393,4 +419,49 @@ func (b *UIRenderer) Draw(rect Rectangle, color Color) {
 	if b.index >= batchMaxSize {
 		b.flush()
 	}
+	return nil
+}
+
+var uiRendererVertCode = `
+attribute vec2 position;
+attribute vec4 texcoords;
+attribute vec2 slicecoords;
+attribute vec2 dimension;
+attribute vec4 color;
+uniform vec2 uProjection;
+varying vec4 vTexCoords;
+varying vec2 vSliceCoords;
+varying vec2 vDimension;
+varying vec4 vColor;
+const vec2 center = vec2(-1.0, 1.0);
+void main() {
+	vTexCoords = texcoords;
+	vSliceCoords = slicecoords;
+	vDimension = dimension;
+	vColor = color;
+	gl_Position = vec4(position.x / uProjection.x + center.x, position.y / -uProjection.y + center.y, 0.0, 1.0);
+}`
+
+var uiRendererFragCode = `
+precision mediump float;
+varying vec4 vTexCoords;
+varying vec2 vSliceCoords;
+varying vec2 vDimension;
+varying vec4 vColor;
+uniform sampler2D uSampler;
+uniform vec2 uBorder;
+float map(float value, float originalMin, float originalMax, float newMin, float newMax) {
+	return (value - originalMin) / (originalMax - originalMin) * (newMax - newMin) + newMin;
+}
+float processAxis(float coord, float textureBorder, float windowBorder) {
+	if (coord < windowBorder)
+		return map(coord, 0.0, windowBorder, 0.0, textureBorder);
+	if (coord < 1.0 - windowBorder)
+		return map(coord, windowBorder, 1.0 - windowBorder, textureBorder, 1.0 - textureBorder);
+	return map(coord, 1.0 - windowBorder, 1.0, 1.0 - textureBorder, 1.0);
 }
+void main(void) {
+	vec2 sliced = vec2(processAxis(vSliceCoords.x, uBorder.x, vDimension.x), processAxis(vSliceCoords.y, uBorder.y, vDimension.y));
+	vec2 size = vTexCoords.zw - vTexCoords.xy;
+	gl_FragColor = vColor * texture2D(uSampler, vTexCoords.xy + size * sliced);
+}`
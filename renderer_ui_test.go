@@ -0,0 +1,142 @@
+package noodle
+
+import "testing"
+
+//renderUI draws a single frame with a new UI renderer, returning the platform it was drawn on
+func renderUI(t *testing.T, draw func(renderer *UIRenderer)) *HeadlessPlatform {
+	t.Helper()
+	app := &headlessApp{}
+	platform := startHeadless(t, app)
+	renderer, err := NewUIRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderer.SetScale(1)
+
+	app.render = func() {
+		if err := renderer.Begin(); err != nil {
+			t.Fatal(err)
+		}
+		draw(renderer)
+		if err := renderer.End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !platform.Step(16) {
+		t.Fatal("no frame was requested")
+	}
+	return platform
+}
+
+func TestUIRendererCompilesEmbeddedShaders(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	if _, err := NewUIRenderer(); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := callsNamed(platform.GL(), "shaderSource")
+	if len(sources) != 2 || sources[0].Args[1] != uiRendererVertCode || sources[1].Args[1] != uiRendererFragCode {
+		t.Fatalf("expected the embedded vertex and fragment shaders, got %d sources", len(sources))
+	}
+
+	//Every attribute is enabled and pointed into the vertices
+	if pointers := callsNamed(platform.GL(), "vertexAttribPointer"); len(pointers) != 5 {
+		t.Errorf("expected 5 attributes, got %d", len(pointers))
+	}
+}
+
+func TestUIRendererBatchesBySprite(t *testing.T) {
+	texture := newTestTexture(t, 16, 16)
+	first := NewSliceSprite(texture, NewRectangle(0, 0, 8, 8), Vector2{2, 2})
+	second := NewSliceSprite(texture, NewRectangle(8, 8, 8, 8), Vector2{4, 4})
+
+	platform := renderUI(t, func(renderer *UIRenderer) {
+		renderer.SetSprite(first)
+		renderer.Draw(NewRectangle(10, 20, 30, 40), White)
+		renderer.Draw(NewRectangle(0, 0, 5, 5), White)
+		renderer.SetSprite(first)
+		renderer.Draw(NewRectangle(0, 0, 5, 5), White)
+		renderer.SetSprite(second)
+		renderer.Draw(NewRectangle(0, 0, 5, 5), White)
+	})
+
+	calls := platform.GL().DrawCalls()
+	if len(calls) != 2 || calls[0].Count != 3*6 || calls[1].Count != 6 {
+		t.Fatalf("expected a batch for each sprite, got %+v", calls)
+	}
+
+	//Each batch sets the border of its own sprite, relative to its size
+	borders := callsNamed(platform.GL(), "uniform2fv")
+	if len(borders) != 2 || borders[0].Args[1] != (Vector2{0.25, 0.25}) || borders[1].Args[1] != (Vector2{0.5, 0.5}) {
+		t.Errorf("unexpected borders %v", borders)
+	}
+
+	//The first vertex is the top left, the third is the bottom right
+	data := callsNamed(platform.GL(), "bufferSubData")[0].Args[2].([]byte)
+	corners := [4]float32{vertexFloat(data, 0), vertexFloat(data, 1), vertexFloat(data, 22), vertexFloat(data, 23)}
+	if corners != [4]float32{10, 20, 40, 60} {
+		t.Errorf("unexpected corners %v", corners)
+	}
+	if u, v := vertexFloat(data, 2), vertexFloat(data, 5); u != 0 || v != 0.5 {
+		t.Errorf("expected the UVs of the first sprite, got %v and %v", u, v)
+	}
+}
+
+func TestUIRendererFlushesFullBatch(t *testing.T) {
+	sprite := NewSliceSprite(newTestTexture(t, 1, 1), NewRectangle(0, 0, 1, 1), Vector2{})
+	platform := renderUI(t, func(renderer *UIRenderer) {
+		renderer.SetSprite(sprite)
+		for i := 0; i < batchMaxSize+1; i++ {
+			renderer.Draw(NewRectangle(0, 0, 1, 1), White)
+		}
+	})
+
+	calls := platform.GL().DrawCalls()
+	if len(calls) != 2 || calls[0].Count != batchMaxSize*6 || calls[1].Count != 6 {
+		t.Fatalf("expected a full batch then a single rectangle, got %+v", calls)
+	}
+}
+
+func TestUIRendererRequiresBeginAndSprite(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	renderer, err := NewUIRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sprite := NewSliceSprite(newTestTexture(t, 1, 1), NewRectangle(0, 0, 1, 1), Vector2{})
+
+	if err := renderer.SetSprite(sprite); err != ErrNotDrawing {
+		t.Errorf("SetSprite before Begin returned %v", err)
+	}
+	if err := renderer.Draw(NewRectangle(0, 0, 1, 1), White); err != ErrNotDrawing {
+		t.Errorf("Draw before Begin returned %v", err)
+	}
+	if err := renderer.End(); err != ErrNotDrawing {
+		t.Errorf("End before Begin returned %v", err)
+	}
+
+	if err := renderer.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	if err := renderer.Begin(); err != ErrAlreadyDrawing {
+		t.Errorf("Begin while drawing returned %v", err)
+	}
+	if err := renderer.Draw(NewRectangle(0, 0, 1, 1), White); err != ErrNoSprite {
+		t.Errorf("Draw without a sprite returned %v", err)
+	}
+	if err := renderer.SetSprite(nil); err != ErrNoSprite {
+		t.Errorf("SetSprite with nil returned %v", err)
+	}
+	if err := renderer.SetSprite(sprite); err != nil {
+		t.Fatal(err)
+	}
+	if err := renderer.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	//Begin clears the sprite, so it has to be set again each frame
+	renderer.Begin()
+	if err := renderer.Draw(NewRectangle(0, 0, 1, 1), White); err != ErrNoSprite {
+		t.Errorf("Draw after Begin kept the previous sprite, returned %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package noodle
+
+import "log"
+
+//Scene is a single state of the game, such as a menu, a level or a pause overlay. Scenes are run by a SceneManager and
+// may implement the same optional interfaces as an Application, such as Stopper, Pauser and Resizer.
+type Scene interface {
+	Application
+}
+
+//Transition is drawn over the scenes while the SceneManager changes between them
+type Transition interface {
+	//Duration is how long the transition takes in seconds. The scenes are changed half way through.
+	Duration() float32
+	//Render draws the transition over the scenes. Progress goes from 0 to 1.
+	Render(progress float32)
+}
+
+//sceneEntry is a scene in the stack
+type sceneEntry struct {
+	scene   Scene
+	overlay bool
+}
+
+//sceneChange is a change to the stack that has been requested but not yet applied
+type sceneChange struct {
+	apply   func()
+	animate bool
+}
+
+//SceneManager is an Application that runs a stack of Scenes. Only the top scene is updated, while overlay scenes
+// let the scenes below them render first, so a pause menu can be drawn over the level.
+//
+//Changes to the stack are applied after the current Update, so scenes can safely push, pop and replace themselves.
+type SceneManager struct {
+	//Transition is played when scenes are pushed, popped or replaced. If nil, scenes change instantly. Overlays never transition.
+	Transition Transition
+
+	//OnStartFailed is called with a scene whose Start returned false. The scene is not added to the stack.
+	// If nil, the failure is logged.
+	OnStartFailed func(scene Scene)
+
+	initial Scene
+	stack   []sceneEntry
+	pending []sceneChange
+
+	transition Transition
+	elapsed    float32
+	change     func()
+	changed    bool
+}
+
+//NewSceneManager creates a new scene manager that will start with the scene
+func NewSceneManager(scene Scene) *SceneManager {
+	return &SceneManager{initial: scene}
+}
+
+//Top gets the scene on the top of the stack, or nil if there are none
+func (m *SceneManager) Top() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1].scene
+}
+
+//Len gets the number of scenes in the stack
+func (m *SceneManager) Len() int { return len(m.stack) }
+
+//IsTransitioning checks if a transition is playing
+func (m *SceneManager) IsTransitioning() bool { return m.transition != nil }
+
+//Push starts the scene and places it on top of the stack
+func (m *SceneManager) Push(scene Scene) {
+	m.request(func() { m.push(scene, false) }, true)
+}
+
+//PushOverlay starts the scene and places it on top of the stack, while still rendering the scenes below it
+func (m *SceneManager) PushOverlay(scene Scene) {
+	m.request(func() { m.push(scene, true) }, false)
+}
+
+//Pop stops the top scene and removes it from the stack
+func (m *SceneManager) Pop() {
+	animate := len(m.stack) > 0 && !m.stack[len(m.stack)-1].overlay
+	m.request(m.pop, animate)
+}
+
+//Replace stops the top scene and replaces it with the scene
+func (m *SceneManager) Replace(scene Scene) {
+	m.request(func() {
+		m.pop()
+		m.push(scene, false)
+	}, true)
+}
+
+//request queues a change to be applied once it is safe to
+func (m *SceneManager) request(apply func(), animate bool) {
+	m.pending = append(m.pending, sceneChange{apply, animate})
+}
+
+//applyPending applies the queued changes, stopping at the first that needs a transition
+func (m *SceneManager) applyPending() {
+	for m.transition == nil && len(m.pending) > 0 {
+		change := m.pending[0]
+		m.pending = m.pending[1:]
+
+		if change.animate && m.Transition != nil {
+			m.transition = m.Transition
+			m.elapsed = 0
+			m.change = change.apply
+			m.changed = false
+			return
+		}
+
+		change.apply()
+	}
+}
+
+//push starts the scene and adds it to the stack
+func (m *SceneManager) push(scene Scene, overlay bool) {
+	if !scene.Start() {
+		if m.OnStartFailed != nil {
+			m.OnStartFailed(scene)
+		} else {
+			log.Printf("scene %T failed to start", scene)
+		}
+		return
+	}
+	m.stack = append(m.stack, sceneEntry{scene, overlay})
+}
+
+//pop stops the top scene and removes it from the stack
+func (m *SceneManager) pop() {
+	if len(m.stack) == 0 {
+		return
+	}
+
+	top := m.stack[len(m.stack)-1].scene
+	m.stack = m.stack[:len(m.stack)-1]
+	if stopper, ok := top.(Stopper); ok {
+		stopper.Stop()
+	}
+}
+
+//Start starts the initial scene
+func (m *SceneManager) Start() bool {
+	if m.initial != nil {
+		m.push(m.initial, false)
+		m.initial = nil
+		if len(m.stack) == 0 {
+			return false
+		}
+	}
+
+	m.applyPending()
+	return true
+}
+
+//Update updates the top scene, advances the transition and applies any changes the scenes asked for
+func (m *SceneManager) Update(dt float32) {
+	if top := m.Top(); top != nil {
+		top.Update(dt)
+	}
+
+	if m.transition != nil {
+		m.elapsed += dt
+		duration := m.transition.Duration()
+
+		//Change the scenes half way through the transition
+		if !m.changed && m.elapsed >= duration/2 {
+			m.change()
+			m.changed = true
+		}
+
+		if m.elapsed >= duration {
+			m.transition = nil
+			m.change = nil
+		}
+	}
+
+	m.applyPending()
+}
+
+//Render renders the top scene, along with every scene under it that is visible through overlays, then the transition
+func (m *SceneManager) Render() {
+	first := len(m.stack) - 1
+	for first > 0 && m.stack[first].overlay {
+		first--
+	}
+
+	for i := first; i >= 0 && i < len(m.stack); i++ {
+		m.stack[i].scene.Render()
+	}
+
+	if m.transition != nil {
+		progress := float32(1)
+		if duration := m.transition.Duration(); duration > 0 {
+			progress = Clamp32(m.elapsed/duration, 0, 1)
+		}
+		m.transition.Render(progress)
+	}
+}
+
+//FixedUpdate passes the fixed update to the top scene
+func (m *SceneManager) FixedUpdate(dt float32) {
+	if updater, ok := m.Top().(FixedUpdater); ok {
+		updater.FixedUpdate(dt)
+	}
+}
+
+//Stop stops every scene, from the top of the stack down
+func (m *SceneManager) Stop() {
+	for len(m.stack) > 0 {
+		m.pop()
+	}
+	m.pending = nil
+	m.transition = nil
+}
+
+//Pause tells every scene the application has been paused
+func (m *SceneManager) Pause() {
+	for _, entry := range m.stack {
+		if pauser, ok := entry.scene.(Pauser); ok {
+			pauser.Pause()
+		}
+	}
+}
+
+//Resume tells every scene the application has been resumed
+func (m *SceneManager) Resume() {
+	for _, entry := range m.stack {
+		if pauser, ok := entry.scene.(Pauser); ok {
+			pauser.Resume()
+		}
+	}
+}
+
+//Focus tells every scene the canvas gained focus
+func (m *SceneManager) Focus() {
+	for _, entry := range m.stack {
+		if focuser, ok := entry.scene.(Focuser); ok {
+			focuser.Focus()
+		}
+	}
+}
+
+//Blur tells every scene the canvas lost focus
+func (m *SceneManager) Blur() {
+	for _, entry := range m.stack {
+		if focuser, ok := entry.scene.(Focuser); ok {
+			focuser.Blur()
+		}
+	}
+}
+
+//Resized tells every scene the canvas changed size
+func (m *SceneManager) Resized(width, height int) {
+	for _, entry := range m.stack {
+		if resizer, ok := entry.scene.(Resizer); ok {
+			resizer.Resized(width, height)
+		}
+	}
+}
+
+//ContextLost tells every scene the GL context was lost
+func (m *SceneManager) ContextLost() {
+	for _, entry := range m.stack {
+		if restorer, ok := entry.scene.(ContextRestorer); ok {
+			restorer.ContextLost()
+		}
+	}
+}
+
+//ContextRestored tells every scene the GL context was restored
+func (m *SceneManager) ContextRestored() {
+	for _, entry := range m.stack {
+		if restorer, ok := entry.scene.(ContextRestorer); ok {
+			restorer.ContextRestored()
+		}
+	}
+}
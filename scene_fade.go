@@ -0,0 +1,95 @@
+package noodle
+
+import "log"
+
+//FadeTransition fades the screen out to a colour, changes the scene, then fades back in
+type FadeTransition struct {
+	Color Color   //Color is the colour the screen fades to
+	Time  float32 //Time is how long the whole fade takes in seconds
+
+	shader   *Shader
+	buffer   WebGLBuffer
+	position WebGLAttributeLocation
+	uColor   WebGLUniformLocation
+	context  *Context //context is the context the GL objects were made in
+	err      error    //err is why the shader could not be compiled
+}
+
+//NewFadeTransition creates a new fade to the colour that takes the time in seconds
+func NewFadeTransition(color Color, time float32) *FadeTransition {
+	return &FadeTransition{Color: color, Time: time}
+}
+
+//Duration is how long the fade takes
+func (fade *FadeTransition) Duration() float32 { return fade.Time }
+
+//Err gets why the fade could not be drawn. Once its shader fails to compile the fade is skipped, so the scenes change
+// without it.
+func (fade *FadeTransition) Err() error { return fade.err }
+
+//Render covers the screen with the colour. It is fully opaque half way through, when the scenes change.
+func (fade *FadeTransition) Render(progress float32) {
+	alpha := 1 - Abs32(progress*2-1)
+	if alpha <= 0 || fade.err != nil {
+		return
+	}
+
+	//The GL objects are only made once they are needed, as there might not be a context when the fade is created
+	if fade.shader == nil {
+		shader, err := LoadShader(fadeTransitionVertCode, fadeTransitionFragCode)
+		if err != nil {
+			fade.err = err
+			log.Println("Failed to compile fade shader, the fade will be skipped!", err)
+			return
+		}
+
+		fade.shader = shader
+
+		fade.Restore()
+		fade.context = track(fade)
+	}
+
+	color := fade.Color.Normalize()
+	color.W *= alpha
+
+	fade.shader.Use()
+	GL.BindBuffer(GlArrayBuffer, fade.buffer)
+	GL.VertexAttribPointer(fade.position, 2, GlFloat, false, 0, 0)
+	GL.EnableVertexAttribArray(fade.position)
+	GL.Uniform4v(fade.uColor, color)
+
+	GL.Disable(GlDepthTest)
+	GL.Enable(GlBlend)
+	GL.BlendFunc(GlSrcAlpha, GlOneMinusSrcAlpha)
+	GL.DrawArrays(GlTriangles, 0, 3)
+	GL.DisableVertexAttribArray(fade.position)
+}
+
+//Restore queries the shader locations and creates the triangle that covers the screen
+func (fade *FadeTransition) Restore() error {
+	fade.position = fade.shader.GetAttribLocation("position")
+	fade.uColor = fade.shader.GetUniformLocation("uColor")
+	fade.buffer = GL.NewBuffer(GlArrayBuffer, []float32{-1, -1, 3, -1, -1, 3}, GlStaticDraw)
+	return nil
+}
+
+//Release deletes the buffer and shader of the fade
+func (fade *FadeTransition) Release() {
+	if fade.shader == nil {
+		return
+	}
+
+	fade.context.gl.DeleteBuffer(fade.buffer)
+	fade.shader.Release()
+	fade.shader = nil
+	fade.context.Untrack(fade)
+}
+
+var fadeTransitionVertCode = `
+attribute vec2 position;
+void main() { gl_Position = vec4(position, 0.0, 1.0); }`
+
+var fadeTransitionFragCode = `
+precision mediump float;
+uniform vec4 uColor;
+void main() { gl_FragColor = uColor; }`
@@ -0,0 +1,259 @@
+package noodle
+
+import (
+	"strings"
+	"testing"
+)
+
+//testScene is a scene that records its lifecycle, calling update from inside its Update
+type testScene struct {
+	name    string
+	events  *[]string
+	update  func()
+	started bool
+	fail    bool
+}
+
+//record adds the event to the log, if the scene has one
+func (scene *testScene) record(event string) {
+	if scene.events != nil {
+		*scene.events = append(*scene.events, scene.name+" "+event)
+	}
+}
+
+func (scene *testScene) Start() bool {
+	scene.started = true
+	scene.record("start")
+	return !scene.fail
+}
+
+func (scene *testScene) Update(float32) {
+	scene.record("update")
+	if scene.update != nil {
+		scene.update()
+	}
+}
+
+func (scene *testScene) Render() { scene.record("render") }
+func (scene *testScene) Stop()   { scene.record("stop") }
+
+//testTransition records the progress of each Render
+type testTransition struct {
+	duration float32
+	progress []float32
+}
+
+func (transition *testTransition) Duration() float32 { return transition.duration }
+func (transition *testTransition) Render(progress float32) {
+	transition.progress = append(transition.progress, progress)
+}
+
+//expectEvents checks the scenes logged the events since the last check, then clears the log
+func expectEvents(t *testing.T, events *[]string, expected ...string) {
+	t.Helper()
+	if got := strings.Join(*events, ", "); got != strings.Join(expected, ", ") {
+		t.Errorf("expected [%s], got [%s]", strings.Join(expected, ", "), got)
+	}
+	*events = nil
+}
+
+//startScenes starts a manager with the scenes pushed on top of each other, without a transition
+func startScenes(t *testing.T, scenes ...*testScene) *SceneManager {
+	t.Helper()
+	manager := NewSceneManager(scenes[0])
+	for _, scene := range scenes[1:] {
+		manager.Push(scene)
+	}
+	if !manager.Start() || manager.Len() != len(scenes) {
+		t.Fatalf("expected %d scenes to start, got %d", len(scenes), manager.Len())
+	}
+	return manager
+}
+
+func TestSceneManagerReportsFailedStart(t *testing.T) {
+	menu := &testScene{}
+	manager := NewSceneManager(menu)
+	var failed []Scene
+	manager.OnStartFailed = func(scene Scene) { failed = append(failed, scene) }
+	platform := startHeadless(t, manager)
+
+	broken := &testScene{fail: true}
+	manager.Push(broken)
+	platform.Step(16)
+
+	if !broken.started || len(failed) != 1 || failed[0] != broken {
+		t.Fatalf("expected the broken scene to be reported, got %v", failed)
+	}
+	if manager.Top() != menu || manager.Len() != 1 {
+		t.Errorf("the broken scene was added to the stack")
+	}
+}
+
+func TestSceneManagerDefersChanges(t *testing.T) {
+	var events []string
+	menu := &testScene{name: "menu", events: &events}
+	level := &testScene{name: "level", events: &events}
+	pause := &testScene{name: "pause", events: &events}
+	manager := startScenes(t, menu)
+	expectEvents(t, &events, "menu start")
+
+	//Each change is only applied once the scene asking for it has finished its Update
+	menu.update = func() {
+		manager.Push(level)
+		if manager.Top() != menu || level.started {
+			t.Error("the push was applied during Update")
+		}
+	}
+	manager.Update(0.1)
+	expectEvents(t, &events, "menu update", "level start")
+	if manager.Top() != level || manager.Len() != 2 {
+		t.Fatalf("expected the level on top of 2 scenes, got %v", manager.Top())
+	}
+
+	level.update = func() {
+		manager.Replace(pause)
+		if manager.Top() != level || pause.started {
+			t.Error("the replace was applied during Update")
+		}
+	}
+	manager.Update(0.1)
+	expectEvents(t, &events, "level update", "level stop", "pause start")
+	if manager.Top() != pause || manager.Len() != 2 {
+		t.Fatalf("expected the pause to replace the level, got %v", manager.Top())
+	}
+
+	pause.update = func() {
+		manager.Pop()
+		if manager.Top() != pause {
+			t.Error("the pop was applied during Update")
+		}
+	}
+	manager.Update(0.1)
+	expectEvents(t, &events, "pause update", "pause stop")
+	if manager.Top() != menu || manager.Len() != 1 {
+		t.Errorf("expected only the menu to be left, got %d scenes", manager.Len())
+	}
+}
+
+func TestSceneManagerTransitionChangesHalfway(t *testing.T) {
+	var events []string
+	menu := &testScene{name: "menu", events: &events}
+	level := &testScene{name: "level", events: &events}
+	manager := startScenes(t, menu)
+	transition := &testTransition{duration: 1}
+	manager.Transition = transition
+
+	//The transition starts once the frame's Update is done
+	manager.Push(level)
+	manager.Update(0.25)
+	if !manager.IsTransitioning() || manager.Top() != menu || level.started {
+		t.Fatal("expected the transition to start without changing scenes")
+	}
+
+	manager.Update(0.25)
+	manager.Update(0.125)
+	if manager.Top() != menu || level.started {
+		t.Fatal("the scenes changed before half way")
+	}
+	manager.Render()
+
+	manager.Update(0.125)
+	if manager.Top() != level || !manager.IsTransitioning() {
+		t.Fatal("expected the level to be on top half way through the transition")
+	}
+	manager.Render()
+	expectEvents(t, &events, "menu start", "menu update", "menu update", "menu update", "menu render", "menu update", "level start", "level render")
+
+	manager.Update(0.5)
+	if manager.IsTransitioning() {
+		t.Error("the transition did not finish")
+	}
+	manager.Render()
+	if len(transition.progress) != 2 || transition.progress[0] != 0.375 || transition.progress[1] != 0.5 {
+		t.Errorf("expected the transition to render at 0.375 and 0.5, got %v", transition.progress)
+	}
+}
+
+func TestSceneManagerOverlaysRenderBelow(t *testing.T) {
+	var events []string
+	menu := &testScene{name: "menu", events: &events}
+	level := &testScene{name: "level", events: &events}
+	pause := &testScene{name: "pause", events: &events}
+	options := &testScene{name: "options", events: &events}
+	manager := startScenes(t, menu, level)
+
+	//Overlays render from the first scene that is not an overlay, while only the top updates
+	manager.PushOverlay(pause)
+	manager.PushOverlay(options)
+	manager.Update(0.1)
+	events = nil
+	manager.Update(0.1)
+	manager.Render()
+	expectEvents(t, &events, "options update", "level render", "pause render", "options render")
+
+	//A scene that is not an overlay hides everything below it
+	shop := &testScene{name: "shop", events: &events}
+	manager.Push(shop)
+	manager.Update(0.1)
+	events = nil
+	manager.Render()
+	expectEvents(t, &events, "shop render")
+}
+
+func TestSceneManagerStopsFromTheTop(t *testing.T) {
+	var events []string
+	menu := &testScene{name: "menu", events: &events}
+	level := &testScene{name: "level", events: &events}
+	pause := &testScene{name: "pause", events: &events}
+	manager := startScenes(t, menu, level)
+	manager.PushOverlay(pause)
+	manager.Update(0.1)
+
+	//Changes that have not been applied yet are dropped
+	ignored := &testScene{name: "ignored", events: &events}
+	manager.Push(ignored)
+	events = nil
+	manager.Stop()
+	expectEvents(t, &events, "pause stop", "level stop", "menu stop")
+	if manager.Len() != 0 || manager.Top() != nil || ignored.started {
+		t.Errorf("expected every scene to be stopped, %d are left", manager.Len())
+	}
+}
+
+func TestFadeTransitionSkipsBrokenShader(t *testing.T) {
+	previous := fadeTransitionVertCode
+	fadeTransitionVertCode = ""
+	defer func() { fadeTransitionVertCode = previous }()
+
+	platform := startHeadless(t, &headlessApp{})
+	fade := NewFadeTransition(Black, 1)
+	fade.Render(0.5)
+	fade.Render(0.5)
+
+	if fade.Err() == nil {
+		t.Fatal("expected the compile error to be kept")
+	}
+	if compiles := callsNamed(platform.GL(), "compileShader"); len(compiles) != 1 {
+		t.Errorf("expected a single attempt to compile, got %d", len(compiles))
+	}
+	if len(platform.GL().DrawCalls()) != 0 {
+		t.Error("the fade was drawn without a shader")
+	}
+}
+
+func TestFadeTransitionDisablesAttribute(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	fade := NewFadeTransition(Black, 1)
+	fade.Render(0.5)
+
+	if fade.Err() != nil {
+		t.Fatal(fade.Err())
+	}
+	if len(platform.GL().DrawCalls()) != 1 {
+		t.Fatal("the fade was not drawn")
+	}
+	calls := platform.GL().Calls()
+	if last := calls[len(calls)-1]; last.Name != "disableVertexAttribArray" || last.Args[0] != fade.position {
+		t.Errorf("expected the position to be disabled after drawing, got %v", last)
+	}
+}
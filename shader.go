@@ -1,45 +1,164 @@
 package noodle
 
-//Shader holds the shaders
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	//ErrUnknownUniform is returned when a shader does not have an active uniform with the name. Uniforms the compiler
+	// decides are unused are not active either.
+	ErrUnknownUniform = errors.New("shader has no active uniform")
+	//ErrUnknownAttribute is returned when a shader does not have an active attribute with the name
+	ErrUnknownAttribute = errors.New("shader has no active attribute")
+)
+
+//ShaderStage is the step of building a shader program
+type ShaderStage string
+
+const (
+	ShaderStageVertex   ShaderStage = "vertex"   //ShaderStageVertex is compiling the vertex shader
+	ShaderStageFragment ShaderStage = "fragment" //ShaderStageFragment is compiling the fragment shader
+	ShaderStageLink     ShaderStage = "link"     //ShaderStageLink is linking the shaders into a program
+)
+
+//ShaderCompileError is the error of a shader that failed to compile or link. It matches ErrShaderCompile with errors.Is.
+type ShaderCompileError struct {
+	Stage ShaderStage
+	Log   string //Log is the info log from GL. The lines of preprocessed sources refer to the files they came from.
+}
+
+//Error formats the stage and log
+func (e *ShaderCompileError) Error() string {
+	if e.Stage == ShaderStageLink {
+		return "shader failed to link: " + e.Log
+	}
+	return string(e.Stage) + " shader failed to compile: " + e.Log
+}
+
+//Unwrap gets ErrShaderCompile
+func (e *ShaderCompileError) Unwrap() error { return ErrShaderCompile }
+
+//compileError makes the error of a failed stage, mapping the log back to the files of the source
+func compileError(stage ShaderStage, source *ShaderSource, err error) error {
+	log := err.Error()
+	if source != nil {
+		log = source.MapInfoLog(log)
+	}
+	return fail(&ShaderCompileError{Stage: stage, Log: log})
+}
+
+//ShaderUniform is an active uniform of a shader
+type ShaderUniform struct {
+	WebGLActiveInfo
+	Location WebGLUniformLocation
+}
+
+//ShaderAttribute is an active attribute of a shader
+type ShaderAttribute struct {
+	WebGLActiveInfo
+	Location WebGLAttributeLocation
+}
+
+//Shader holds the shaders. The active uniforms and attributes are found when it is linked, so their locations are cached.
 type Shader struct {
 	program WebGLShaderProgram
+	vert    *ShaderSource
+	frag    *ShaderSource
+
+	uniforms       []ShaderUniform
+	attributes     []ShaderAttribute
+	uniformIndex   map[string]int //uniformIndex is the index of each uniform by name. Arrays can be found with or without [0].
+	attributeIndex map[string]int
+	elements       map[string]WebGLUniformLocation //elements are the locations of array elements past the first that have been asked for
+
+	generation int       //generation counts how many times the program has been restored, so anything cached from it can tell it is stale
+	material   *Material //material is the last material that set the program's uniforms
+	context    *Context  //context is the context the program was compiled in
 }
 
-//LoadShaderFromURL loads a shader from a URL
+//LoadShaderFromURL reads the vertex and fragment files from Files and preprocesses them, so they can #include other files
+// relative to themselves. Use a ShaderLibrary to add defines or compile variants.
 func LoadShaderFromURL(vertURL, fragURL string) (*Shader, error) {
 
 	//Load the vertext shader
-	vertCode, err := DownloadString(vertURL)
+	vert, err := PreprocessShader(ReadString, vertURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	//Load the frag shader
-	fragCode, err := DownloadString(fragURL)
+	frag, err := PreprocessShader(ReadString, fragURL, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	return LoadShader(vertCode, fragCode)
+	return LoadShaderSource(vert, frag)
 }
 
-//LoadShader loads a shader from code
+//LoadShader loads a shader from code. The shader keeps its source so it can be recompiled if the context is lost.
 func LoadShader(vertCode, fragCode string) (*Shader, error) {
-	vertex, err := GL.NewShader(GlVertexShader, vertCode)
-	defer GL.DeleteShader(vertex)
+	return LoadShaderSource(&ShaderSource{Code: vertCode}, &ShaderSource{Code: fragCode})
+}
+
+//LoadShaderSource loads a shader from preprocessed code. Compile errors are a *ShaderCompileError, with a log that refers
+// to the files and lines the code came from.
+func LoadShaderSource(vert, frag *ShaderSource) (*Shader, error) {
+	program, err := compileProgram(vert, frag)
 	if err != nil {
 		return nil, err
 	}
 
-	fragment, err := GL.NewShader(GlFragmentShader, fragCode)
+	shader := &Shader{program: program, vert: vert, frag: frag}
+	shader.reflect()
+	shader.context = track(shader)
+	return shader, nil
+}
+
+//compileProgram compiles the vertex and fragment code and links them into a program
+func compileProgram(vert, frag *ShaderSource) (WebGLShaderProgram, error) {
+	vertex, err := GL.NewShader(GlVertexShader, vert.Code)
+	defer GL.DeleteShader(vertex)
+	if err != nil {
+		return nil, compileError(ShaderStageVertex, vert, err)
+	}
+
+	fragment, err := GL.NewShader(GlFragmentShader, frag.Code)
 	defer GL.DeleteShader(fragment)
 	if err != nil {
-		return nil, err
+		return nil, compileError(ShaderStageFragment, frag, err)
 	}
 
 	program, err := GL.NewProgram([]WebGLShader{vertex, fragment})
 	if err != nil {
-		return nil, err
+		GL.DeleteProgram(program)
+		return nil, compileError(ShaderStageLink, nil, err)
+	}
+	return program, nil
+}
+
+//Restore recompiles the shader from its source after the context has been lost
+func (shader *Shader) Restore() error {
+	program, err := compileProgram(shader.vert, shader.frag)
+	if err != nil {
+		return err
 	}
 
-	return &Shader{program}, nil
+	shader.program = program
+	shader.reflect()
+	shader.generation++
+	shader.material = nil
+	return nil
+}
+
+//Release deletes the shader program. It will no longer be restored if the context is lost.
+func (shader *Shader) Release() {
+	if shader.program != nil {
+		shader.context.gl.DeleteProgram(shader.program)
+		shader.program = nil
+	}
+	shader.context.Untrack(shader)
 }
 
 //GetProgram gets the shader program
@@ -47,14 +166,110 @@ func (shader *Shader) GetProgram() WebGLShaderProgram {
 	return shader.program
 }
 
-//GetUniformLocation returns the location of a specific uniform variable which is part of a given WebGLProgram.
+//reflect finds the active uniforms and attributes of the program and caches their locations
+func (shader *Shader) reflect() {
+	shader.uniforms = nil
+	shader.uniformIndex = make(map[string]int)
+	shader.elements = make(map[string]WebGLUniformLocation)
+	count, _ := GL.GetProgramParameter(shader.program, GlActiveUniforms).(int)
+	for i := 0; i < count; i++ {
+		info, err := GL.GetActiveUniform(shader.program, i)
+		if err != nil {
+			continue
+		}
+
+		shader.uniformIndex[info.Name] = len(shader.uniforms)
+		shader.uniformIndex[strings.TrimSuffix(info.Name, "[0]")] = len(shader.uniforms)
+		shader.uniforms = append(shader.uniforms, ShaderUniform{info, GL.GetUniformLocation(shader.program, info.Name)})
+	}
+
+	shader.attributes = nil
+	shader.attributeIndex = make(map[string]int)
+	count, _ = GL.GetProgramParameter(shader.program, GlActiveAttributes).(int)
+	for i := 0; i < count; i++ {
+		info, err := GL.GetActiveAttrib(shader.program, i)
+		if err != nil {
+			continue
+		}
+
+		shader.attributeIndex[info.Name] = len(shader.attributes)
+		shader.attributes = append(shader.attributes, ShaderAttribute{info, GL.GetAttribLocation(shader.program, info.Name)})
+	}
+}
+
+//Uniforms gets the active uniforms of the shader
+func (shader *Shader) Uniforms() []ShaderUniform { return shader.uniforms }
+
+//Attributes gets the active attributes of the shader
+func (shader *Shader) Attributes() []ShaderAttribute { return shader.attributes }
+
+//Uniform gets an active uniform of the shader by name. Arrays can be named with or without [0].
+func (shader *Shader) Uniform(name string) (ShaderUniform, error) {
+	if index, ok := shader.uniformIndex[name]; ok {
+		return shader.uniforms[index], nil
+	}
+	return ShaderUniform{}, fmt.Errorf("%w %q", ErrUnknownUniform, name)
+}
+
+//Attribute gets an active attribute of the shader by name
+func (shader *Shader) Attribute(name string) (ShaderAttribute, error) {
+	if index, ok := shader.attributeIndex[name]; ok {
+		return shader.attributes[index], nil
+	}
+	return ShaderAttribute{}, fmt.Errorf("%w %q", ErrUnknownAttribute, name)
+}
+
+//UniformLocation gets the location of a uniform, or an error if the shader does not have it. Later elements of an array,
+// such as "uLights[2]", are looked up with GL the first time and then cached.
+func (shader *Shader) UniformLocation(name string) (WebGLUniformLocation, error) {
+	uniform, err := shader.Uniform(name)
+	if err == nil {
+		return uniform.Location, nil
+	}
+
+	//Look up other elements of arrays the shader has
+	if location, ok := shader.elements[name]; ok {
+		return location, nil
+	}
+	open := strings.LastIndexByte(name, '[')
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return nil, err
+	}
+	array, arrayErr := shader.Uniform(name[:open])
+	if arrayErr != nil || array.Size <= 1 {
+		return nil, err
+	}
+
+	var element int
+	if _, scanErr := fmt.Sscanf(name[open:], "[%d]", &element); scanErr != nil || element < 0 || element >= array.Size {
+		return nil, err
+	}
+
+	location := GL.GetUniformLocation(shader.program, name)
+	shader.elements[name] = location
+	return location, nil
+}
+
+//AttribLocation gets the location of an attribute, or an error if the shader does not have it
+func (shader *Shader) AttribLocation(name string) (WebGLAttributeLocation, error) {
+	attribute, err := shader.Attribute(name)
+	if err != nil {
+		return -1, err
+	}
+	return attribute.Location, nil
+}
+
+//GetUniformLocation returns the cached location of a uniform, or nil if the shader does not have it. Use UniformLocation
+// to find out why a uniform is missing.
 func (shader *Shader) GetUniformLocation(location string) WebGLUniformLocation {
-	return GL.GetUniformLocation(shader.program, location)
+	result, _ := shader.UniformLocation(location)
+	return result
 }
 
-//GetAttribLocation gets a location of an attribute
+//GetAttribLocation gets the cached location of an attribute, or -1 if the shader does not have it
 func (shader *Shader) GetAttribLocation(attribute string) WebGLAttributeLocation {
-	return GL.GetAttribLocation(shader.program, attribute)
+	result, _ := shader.AttribLocation(attribute)
+	return result
 }
 
 //BindVertexData binds a buffer of vertex data to an attribute
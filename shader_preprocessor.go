@@ -0,0 +1,289 @@
+package noodle
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//ErrInvalidInclude is returned when an #include directive does not name a file in quotes
+var ErrInvalidInclude = errors.New("#include expects \"file\"")
+
+//ShaderLoader loads the source of a shader file by name
+type ShaderLoader func(name string) (string, error)
+
+//URLShaderLoader loads shader files with DownloadString, whatever Files is. Names are URLs, or paths relative to the page.
+func URLShaderLoader() ShaderLoader {
+	return DownloadString
+}
+
+//MapShaderLoader loads shader files from a map of names to code, such as shaders built into the application
+func MapShaderLoader(files map[string]string) ShaderLoader {
+	return func(name string) (string, error) {
+		if code, ok := files[name]; ok {
+			return code, nil
+		}
+		return "", fmt.Errorf("shader file %q does not exist", name)
+	}
+}
+
+//SourceLine is a line of a shader file, counting from 1
+type SourceLine struct {
+	File string
+	Line int
+}
+
+//String formats the line as file:line
+func (l SourceLine) String() string {
+	return l.File + ":" + strconv.Itoa(l.Line)
+}
+
+//ShaderSource is shader code that has been preprocessed. It remembers which file and line each line of the code came
+// from, so compile errors can point at the original files.
+type ShaderSource struct {
+	Code  string
+	lines []SourceLine
+}
+
+//Origin gets where a line of the code, counting from 1, came from. It is false if the line is outside the code or
+// the source was not preprocessed.
+func (source *ShaderSource) Origin(line int) (SourceLine, bool) {
+	if source == nil || line < 1 || line > len(source.lines) {
+		return SourceLine{}, false
+	}
+	return source.lines[line-1], true
+}
+
+//includeDirective matches an #include line, capturing everything after the directive. The directive must be a whole word.
+var includeDirective = regexp.MustCompile(`^\s*#\s*include\b(.*)$`)
+
+//infoLogLine matches the position at the start of a line of a GLSL info log, such as "ERROR: 0:12:"
+var infoLogLine = regexp.MustCompile(`(?m)^(\w+): \d+:(\d+):`)
+
+//MapInfoLog replaces the line numbers in a GLSL info log with the files and lines they came from
+func (source *ShaderSource) MapInfoLog(log string) string {
+	return infoLogLine.ReplaceAllStringFunc(log, func(match string) string {
+		parts := infoLogLine.FindStringSubmatch(match)
+		line, _ := strconv.Atoi(parts[2])
+		origin, ok := source.Origin(line)
+		if !ok {
+			return match
+		}
+		return parts[1] + ": " + origin.String() + ":"
+	})
+}
+
+//PreprocessShader loads a shader file and replaces each #include "file" with the contents of that file. Included files are
+// found relative to the file that includes them, and each file is only included once, so they do not need include guards.
+// The defines are added as #define lines after the #version directive, if there is one. A define with an empty value is
+// only defined, without a value.
+func PreprocessShader(loader ShaderLoader, name string, defines map[string]string) (*ShaderSource, error) {
+	p := &preprocessor{loader: loader, included: make(map[string]bool)}
+	if err := p.include(name, SourceLine{}); err != nil {
+		return nil, err
+	}
+
+	//#version has to come before anything else, so the defines go after it
+	insert := 0
+	for i, line := range p.code {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			if strings.HasPrefix(trimmed, "#version") {
+				insert = i + 1
+			}
+			break
+		}
+	}
+
+	names := make([]string, 0, len(defines))
+	for define := range defines {
+		names = append(names, define)
+	}
+	sort.Strings(names)
+
+	code := make([]string, 0, len(p.code)+len(names))
+	lines := make([]SourceLine, 0, len(p.code)+len(names))
+	code = append(code, p.code[:insert]...)
+	lines = append(lines, p.lines[:insert]...)
+	for i, define := range names {
+		code = append(code, strings.TrimSpace("#define "+define+" "+defines[define]))
+		lines = append(lines, SourceLine{"<defines>", i + 1})
+	}
+	code = append(code, p.code[insert:]...)
+	lines = append(lines, p.lines[insert:]...)
+
+	return &ShaderSource{Code: strings.Join(code, "\n"), lines: lines}, nil
+}
+
+//preprocessor builds the lines of a shader as files are included
+type preprocessor struct {
+	loader   ShaderLoader
+	included map[string]bool
+	code     []string
+	lines    []SourceLine
+}
+
+//include adds the lines of a file, and the files it includes. from is the #include that asked for it.
+func (p *preprocessor) include(name string, from SourceLine) error {
+	if p.included[name] {
+		return nil
+	}
+	p.included[name] = true
+
+	text, err := p.loader(name)
+	if err != nil {
+		if from.File != "" {
+			return fmt.Errorf("%v: %w", from, err)
+		}
+		return err
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		here := SourceLine{name, i + 1}
+		match := includeDirective.FindStringSubmatch(line)
+		if match == nil {
+			p.code = append(p.code, line)
+			p.lines = append(p.lines, here)
+			continue
+		}
+
+		argument := strings.TrimSpace(match[1])
+		if len(argument) < 2 || argument[0] != '"' || strings.IndexByte(argument[1:], '"') < 1 {
+			return fmt.Errorf("%v: %w", here, ErrInvalidInclude)
+		}
+		file := argument[1 : 1+strings.IndexByte(argument[1:], '"')]
+		if err := p.include(includePath(name, file), here); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//includePath finds the name of an included file relative to the file that includes it
+func includePath(from, name string) string {
+	if strings.HasPrefix(name, "/") || strings.Contains(name, "://") {
+		return name
+	}
+
+	//Only join and clean the path of URLs, not the scheme and host. A URL of just a host is the root.
+	prefix, dir := "", from
+	if scheme := strings.Index(from, "://"); scheme >= 0 {
+		if host := strings.IndexByte(from[scheme+3:], '/'); host >= 0 {
+			prefix, dir = from[:scheme+3+host], from[scheme+3+host:]
+		} else {
+			prefix, dir = from, "/"
+		}
+	}
+
+	if slash := strings.LastIndexByte(dir, '/'); slash >= 0 {
+		dir = dir[:slash+1]
+	} else {
+		dir = ""
+	}
+	return prefix + path.Clean(dir+name)
+}
+
+//ShaderLibrary preprocesses and compiles shaders from a loader, caching each variant so it is only compiled once.
+// Files are only loaded once too, so variants of a downloaded shader only download it the first time.
+type ShaderLibrary struct {
+	Defines map[string]string //Defines are added to every variant, unless the variant sets them too
+
+	loader   ShaderLoader
+	files    map[string]string
+	variants map[string]*Shader
+}
+
+//NewShaderLibrary creates a library that loads shader files with the loader
+func NewShaderLibrary(loader ShaderLoader) *ShaderLibrary {
+	return &ShaderLibrary{
+		Defines:  make(map[string]string),
+		loader:   loader,
+		files:    make(map[string]string),
+		variants: make(map[string]*Shader),
+	}
+}
+
+//load loads a file, or gets it from the cache
+func (lib *ShaderLibrary) load(name string) (string, error) {
+	if code, ok := lib.files[name]; ok {
+		return code, nil
+	}
+
+	code, err := lib.loader(name)
+	if err != nil {
+		return "", err
+	}
+	lib.files[name] = code
+	return code, nil
+}
+
+//Load gets a variant of the shader made from the vertex and fragment files. Each define is a name, such as "VERTEX_COLOR",
+// or a name and value, such as "MAX_LIGHTS=4". Variants with the same files and defines are the same shader, which the
+// library owns, so do not release it.
+func (lib *ShaderLibrary) Load(vertName, fragName string, defines ...string) (*Shader, error) {
+	values := parseDefines(lib.Defines, defines)
+	key := variantKey(vertName, fragName, values)
+	if shader, ok := lib.variants[key]; ok {
+		return shader, nil
+	}
+
+	vert, err := PreprocessShader(lib.load, vertName, values)
+	if err != nil {
+		return nil, err
+	}
+	frag, err := PreprocessShader(lib.load, fragName, values)
+	if err != nil {
+		return nil, err
+	}
+
+	shader, err := LoadShaderSource(vert, frag)
+	if err != nil {
+		return nil, err
+	}
+	lib.variants[key] = shader
+	return shader, nil
+}
+
+//parseDefines adds defines such as "VERTEX_COLOR" or "MAX_LIGHTS=4" to a copy of the base defines
+func parseDefines(base map[string]string, defines []string) map[string]string {
+	values := make(map[string]string, len(base)+len(defines))
+	for name, value := range base {
+		values[name] = value
+	}
+	for _, define := range defines {
+		name, value := define, ""
+		if equals := strings.IndexByte(define, '='); equals >= 0 {
+			name, value = define[:equals], define[equals+1:]
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+//variantKey names a variant by its files and sorted defines
+func variantKey(vertName, fragName string, defines map[string]string) string {
+	names := make([]string, 0, len(defines))
+	for name := range defines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	key.WriteString(vertName + "\x00" + fragName)
+	for _, name := range names {
+		key.WriteString("\x00" + name + "=" + defines[name])
+	}
+	return key.String()
+}
+
+//Release releases every variant the library has compiled and forgets the files it loaded
+func (lib *ShaderLibrary) Release() {
+	for _, shader := range lib.variants {
+		shader.Release()
+	}
+	lib.variants = make(map[string]*Shader)
+	lib.files = make(map[string]string)
+}
@@ -0,0 +1,158 @@
+package noodle
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//preprocess preprocesses the file from the files, failing the test if it cannot
+func preprocess(t *testing.T, files map[string]string, name string, defines map[string]string) *ShaderSource {
+	t.Helper()
+	source, err := PreprocessShader(MapShaderLoader(files), name, defines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return source
+}
+
+func TestPreprocessIncludesEachFileOnce(t *testing.T) {
+	source := preprocess(t, map[string]string{
+		"main.glsl":     "#include \"lib/a.glsl\"\n#include \"lib/b.glsl\"\nmain",
+		"lib/a.glsl":    "  #  include \"b.glsl\"\na",
+		"lib/b.glsl":    "#include \"../lib/a.glsl\"\nb",
+		"lib/more.glsl": "unused",
+	}, "main.glsl", nil)
+
+	//b is included by a, so the second include of b and b's include of a are both skipped
+	if source.Code != "b\na\nmain" {
+		t.Fatalf("unexpected code %q", source.Code)
+	}
+	origins := []SourceLine{{"lib/b.glsl", 2}, {"lib/a.glsl", 2}, {"main.glsl", 3}}
+	for i, expected := range origins {
+		if origin, ok := source.Origin(i + 1); !ok || origin != expected {
+			t.Errorf("line %d: expected %v, got %v", i+1, expected, origin)
+		}
+	}
+	if _, ok := source.Origin(len(origins) + 1); ok {
+		t.Error("expected no origin past the end of the code")
+	}
+}
+
+func TestIncludePath(t *testing.T) {
+	cases := []struct{ from, name, expected string }{
+		{"main.glsl", "a.glsl", "a.glsl"},
+		{"shaders/main.glsl", "lib/a.glsl", "shaders/lib/a.glsl"},
+		{"shaders/main.glsl", "../common/a.glsl", "common/a.glsl"},
+		{"shaders/main.glsl", "/common/a.glsl", "/common/a.glsl"},
+		{"http://host/shaders/main.glsl", "../a.glsl", "http://host/a.glsl"},
+		{"http://host/main.glsl", "../../a.glsl", "http://host/a.glsl"},
+		{"http://host", "a.glsl", "http://host/a.glsl"},
+		{"shaders/main.glsl", "http://host/a.glsl", "http://host/a.glsl"},
+	}
+	for _, c := range cases {
+		if path := includePath(c.from, c.name); path != c.expected {
+			t.Errorf("%q from %q: expected %q, got %q", c.name, c.from, c.expected, path)
+		}
+	}
+}
+
+func TestPreprocessDefinesFollowVersion(t *testing.T) {
+	files := map[string]string{
+		"versioned.glsl": "\n#version 300 es\nprecision mediump float;",
+		"plain.glsl":     "precision mediump float;",
+	}
+	defines := map[string]string{"MAX_LIGHTS": "4", "VERTEX_COLOR": ""}
+
+	source := preprocess(t, files, "versioned.glsl", defines)
+	expected := "\n#version 300 es\n#define MAX_LIGHTS 4\n#define VERTEX_COLOR\nprecision mediump float;"
+	if source.Code != expected {
+		t.Errorf("expected %q, got %q", expected, source.Code)
+	}
+	if origin, _ := source.Origin(4); origin != (SourceLine{"<defines>", 2}) {
+		t.Errorf("expected the second define, got %v", origin)
+	}
+	if origin, _ := source.Origin(5); origin != (SourceLine{"versioned.glsl", 3}) {
+		t.Errorf("expected the line after #version, got %v", origin)
+	}
+
+	source = preprocess(t, files, "plain.glsl", defines)
+	if !strings.HasPrefix(source.Code, "#define MAX_LIGHTS 4\n") {
+		t.Errorf("expected the defines first without #version, got %q", source.Code)
+	}
+}
+
+func TestPreprocessErrors(t *testing.T) {
+	invalid := []string{"#include", "#include a.glsl", "#include <a.glsl>", "#include \"a.glsl", "#include \"\""}
+	for _, line := range invalid {
+		files := map[string]string{"main.glsl": "void main() {}\n" + line, "a.glsl": ""}
+		_, err := PreprocessShader(MapShaderLoader(files), "main.glsl", nil)
+		if !errors.Is(err, ErrInvalidInclude) || !strings.HasPrefix(err.Error(), "main.glsl:2: ") {
+			t.Errorf("%s: expected ErrInvalidInclude at main.glsl:2, got %v", line, err)
+		}
+	}
+
+	//Missing files are reported at the include that asked for them
+	files := map[string]string{"main.glsl": "#include \"missing.glsl\""}
+	if _, err := PreprocessShader(MapShaderLoader(files), "main.glsl", nil); err == nil || !strings.HasPrefix(err.Error(), "main.glsl:1: ") {
+		t.Errorf("expected the missing file at main.glsl:1, got %v", err)
+	}
+}
+
+func TestPreprocessOnlyMatchesWholeDirective(t *testing.T) {
+	source := preprocess(t, map[string]string{"main.glsl": "#includes \"a.glsl\"\n#include_next"}, "main.glsl", nil)
+	if source.Code != "#includes \"a.glsl\"\n#include_next" {
+		t.Errorf("expected the lines to be left for the compiler, got %q", source.Code)
+	}
+}
+
+func TestShaderSourceMapInfoLog(t *testing.T) {
+	source := preprocess(t, map[string]string{
+		"main.glsl": "#version 300 es\n#include \"lib.glsl\"\nvoid main() {}",
+		"lib.glsl":  "float helper();",
+	}, "main.glsl", map[string]string{"A": ""})
+
+	log := "ERROR: 0:3: 'helper' : no definition\nWARNING: 0:4: unused\nERROR: 0:99: past the end\nnot a position"
+	expected := "ERROR: lib.glsl:1: 'helper' : no definition\nWARNING: main.glsl:3: unused\nERROR: 0:99: past the end\nnot a position"
+	if mapped := source.MapInfoLog(log); mapped != expected {
+		t.Errorf("expected %q, got %q", expected, mapped)
+	}
+}
+
+func TestShaderLibraryCachesVariants(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	loads := make(map[string]int)
+	files := MapShaderLoader(map[string]string{"unlit.vert": unlitVertCode, "unlit.frag": unlitFragCode})
+	library := NewShaderLibrary(func(name string) (string, error) {
+		loads[name]++
+		return files(name)
+	})
+	library.Defines["A"] = "1"
+
+	shader, err := library.Load("unlit.vert", "unlit.frag", "B=2", "C")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same, _ := library.Load("unlit.vert", "unlit.frag", "C", "B=2", "A=1"); same != shader {
+		t.Error("the same defines in another order compiled another variant")
+	}
+	if other, _ := library.Load("unlit.vert", "unlit.frag", "B=3", "C"); other == shader {
+		t.Error("a different define value gave the same variant")
+	}
+
+	if links := len(callsNamed(platform.GL(), "linkProgram")); links != 2 {
+		t.Errorf("expected 2 variants to be compiled, got %d", links)
+	}
+	if loads["unlit.vert"] != 1 || loads["unlit.frag"] != 1 {
+		t.Errorf("expected each file to be loaded once, got %v", loads)
+	}
+
+	//Releasing forgets the files, so they are loaded again
+	library.Release()
+	if _, err := library.Load("unlit.vert", "unlit.frag"); err != nil {
+		t.Fatal(err)
+	}
+	if loads["unlit.vert"] != 2 {
+		t.Errorf("expected the file to be loaded again after Release, got %v", loads)
+	}
+}
@@ -0,0 +1,105 @@
+package noodle
+
+import (
+	"errors"
+	"testing"
+)
+
+//lightsFragCode has an array of uniforms
+var lightsFragCode = `
+precision mediump float;
+uniform vec4 uColor;
+uniform vec3 uLights[4];
+void main() { gl_FragColor = uColor + vec4(uLights[3], 0.0); }`
+
+//loadLightsShader compiles the unlit vertex shader with the lights
+func loadLightsShader(t *testing.T) *Shader {
+	t.Helper()
+	shader, err := LoadShader(unlitVertCode, lightsFragCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return shader
+}
+
+func TestShaderReflectsUniformsAndAttributes(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	shader := loadLightsShader(t)
+
+	//Arrays are found with or without the [0] that GL adds to their name
+	for _, name := range []string{"uLights", "uLights[0]"} {
+		uniform, err := shader.Uniform(name)
+		if err != nil || uniform.Name != "uLights[0]" || uniform.Size != 4 || uniform.Type != GlFloatVec3 {
+			t.Errorf("%s: unexpected uniform %+v, %v", name, uniform, err)
+		}
+	}
+	if uniform, err := shader.Uniform(UniformColor); err != nil || uniform.Type != GlFloatVec4 {
+		t.Errorf("unexpected colour %+v, %v", uniform, err)
+	}
+	if attribute, err := shader.Attribute(AttributeTexCoord); err != nil || attribute.Type != GlFloatVec2 {
+		t.Errorf("unexpected texcoord %+v, %v", attribute, err)
+	}
+
+	//Looking up what the shader has does not call into GL
+	platform.GL().ResetCalls()
+	shader.UniformLocation("uLights")
+	shader.AttribLocation(AttributePosition)
+	if calls := platform.GL().Calls(); len(calls) != 0 {
+		t.Errorf("expected the locations to be cached, got %v", calls)
+	}
+}
+
+func TestShaderUnknownNames(t *testing.T) {
+	startHeadless(t, &headlessApp{})
+	shader := loadLightsShader(t)
+
+	if _, err := shader.UniformLocation("uMissing"); !errors.Is(err, ErrUnknownUniform) {
+		t.Errorf("expected ErrUnknownUniform, got %v", err)
+	}
+	if _, err := shader.AttribLocation("missing"); !errors.Is(err, ErrUnknownAttribute) {
+		t.Errorf("expected ErrUnknownAttribute, got %v", err)
+	}
+	if shader.GetUniformLocation("uMissing") != nil || shader.GetAttribLocation("missing") != -1 {
+		t.Error("expected the Get functions to return nil and -1 for missing names")
+	}
+
+	//Elements outside the array, or of uniforms that are not arrays, are not looked up
+	for _, name := range []string{"uLights[4]", "uLights[-1]", "uLights[x]", "uColor[1]", "uMissing[1]"} {
+		if _, err := shader.UniformLocation(name); !errors.Is(err, ErrUnknownUniform) {
+			t.Errorf("%s: expected ErrUnknownUniform, got %v", name, err)
+		}
+	}
+}
+
+func TestShaderCachesArrayElements(t *testing.T) {
+	platform := startHeadless(t, &headlessApp{})
+	gl := platform.GL()
+	shader := loadLightsShader(t)
+
+	//The first time an element is asked for it is found with GL, then it is cached
+	gl.ResetCalls()
+	location, err := shader.UniformLocation("uLights[2]")
+	if err != nil || location == nil {
+		t.Fatalf("expected the element to be found, got %v", err)
+	}
+	if lookups := callsNamed(gl, "getUniformLocation"); len(lookups) != 1 || lookups[0].Args[1] != "uLights[2]" {
+		t.Fatalf("expected a single lookup of uLights[2], got %v", lookups)
+	}
+	gl.ResetCalls()
+	if again, _ := shader.UniformLocation("uLights[2]"); again != location || len(gl.Calls()) != 0 {
+		t.Error("expected the element to be cached")
+	}
+
+	//Restoring the shader makes a new program, so the cache is rebuilt
+	if err := shader.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	gl.ResetCalls()
+	restored, err := shader.UniformLocation("uLights[2]")
+	if err != nil || restored == location || len(callsNamed(gl, "getUniformLocation")) != 1 {
+		t.Errorf("expected the element to be looked up again in the restored program, got %v", err)
+	}
+	if uniform, _ := shader.Uniform("uLights"); uniform.Location == nil || uniform.Size != 4 {
+		t.Errorf("expected the uniforms to be reflected again, got %+v", uniform)
+	}
+}
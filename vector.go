@@ -550,6 +550,34 @@ func NewTransform(translation Vector3, rotation Quaternion, scale Vector3) Trans
 	}
 }
 
+//NewTransformMatrix decomposes a matrix made of a translation, rotation and scale back into a transform.
+// Any shear in the matrix is lost.
+func NewTransformMatrix(matrix Matrix) Transform {
+	scale := Vector3{
+		Vector3{matrix.M0, matrix.M1, matrix.M2}.Length(),
+		Vector3{matrix.M4, matrix.M5, matrix.M6}.Length(),
+		Vector3{matrix.M8, matrix.M9, matrix.M10}.Length(),
+	}
+
+	//Remove the scale so only the rotation is left
+	rotation := NewMatrixIdentity()
+	if scale.X != 0 {
+		rotation.M0, rotation.M1, rotation.M2 = matrix.M0/scale.X, matrix.M1/scale.X, matrix.M2/scale.X
+	}
+	if scale.Y != 0 {
+		rotation.M4, rotation.M5, rotation.M6 = matrix.M4/scale.Y, matrix.M5/scale.Y, matrix.M6/scale.Y
+	}
+	if scale.Z != 0 {
+		rotation.M8, rotation.M9, rotation.M10 = matrix.M8/scale.Z, matrix.M9/scale.Z, matrix.M10/scale.Z
+	}
+
+	return Transform{
+		Position: Vector3{matrix.M12, matrix.M13, matrix.M14},
+		Rotation: NewQuaternionMatrix(rotation).Normalize(),
+		Scale:    scale,
+	}
+}
+
 //ToMatrix turns this tranform into a matrix that performs it.
 func (t Transform) ToMatrix() Matrix {
 	return NewMatrixTransform(t)